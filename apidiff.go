@@ -0,0 +1,431 @@
+package godeep
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"sort"
+	"strings"
+)
+
+type APIKind int
+
+const (
+	APIPackage APIKind = iota
+	APIFunc
+	APIMethod
+	APIType
+	APIField
+	APIInterfaceMethod
+	APIVariable
+	APIConstant
+)
+
+func (k APIKind) String() string {
+	switch k {
+	case APIPackage:
+		return "package"
+	case APIFunc:
+		return "func"
+	case APIMethod:
+		return "method"
+	case APIType:
+		return "type"
+	case APIField:
+		return "field"
+	case APIInterfaceMethod:
+		return "interface method"
+	case APIVariable:
+		return "var"
+	case APIConstant:
+		return "const"
+	}
+	return "unknown"
+}
+
+// APISymbol is an exported item of a package. Symbols are identified by their kind and name,
+// i.e. 'Client' for a type, 'Client.Do' for a method or 'Client.Timeout' for a field, and
+// Signature, Receiver and Tag are compared to detect the changes.
+type APISymbol struct {
+	Kind      APIKind
+	Name      string
+	Signature string
+	// Receiver is the receiver type of the methods, i.e. 'Client' or '*Client'
+	Receiver string
+	// Tag is the struct tag of the fields
+	Tag string
+}
+
+// declaration returns the signature of the symbol, with the receiver for the methods and the
+// tag for the fields.
+func (s APISymbol) declaration() string {
+	if s.Tag != "" {
+		return fmt.Sprintf("%s `%s`", s.Signature, s.Tag)
+	}
+	if s.Receiver == "" {
+		return s.Signature
+	}
+	return fmt.Sprintf("func (%s) %s%s", s.Receiver, s.Name[strings.LastIndex(s.Name, ".")+1:], s.Signature)
+}
+
+// typeSignature returns the kind of the structs and interfaces and the underlying type of the
+// other types, the types of the snapshots which did not have the kinds have no signature.
+func typeSignature(t Type) string {
+	params := typeParamsString(t.TypeParams)
+	switch t.Kind {
+	case TypeUnknown:
+		return ""
+	case TypeStruct, TypeInterface:
+		return params + t.Kind.String()
+	case TypeAlias:
+		return params + "= " + t.Underlying
+	}
+	return params + t.Underlying
+}
+
+// API returns the exported symbols of the package, sorted by name.
+func (p *Package) API() []APISymbol {
+	var symbols []APISymbol
+	for _, fn := range p.exportedFunctions {
+		name := fn
+		if idx := strings.IndexAny(fn, "(["); idx > 0 {
+			name = fn[:idx]
+		}
+		symbols = append(symbols, APISymbol{Kind: APIFunc, Name: name, Signature: fn})
+	}
+	for _, t := range p.exportedTypes {
+		symbols = append(symbols, APISymbol{Kind: APIType, Name: t.Name, Signature: typeSignature(t)})
+		for _, f := range t.Fields {
+			symbols = append(symbols, APISymbol{
+				Kind:      APIField,
+				Name:      fmt.Sprintf("%s.%s", t.Name, f.Name),
+				Signature: f.Type,
+				Tag:       f.Tag,
+			})
+		}
+		for _, e := range t.Embedded {
+			symbols = append(symbols, APISymbol{
+				Kind:      APIField,
+				Name:      fmt.Sprintf("%s.%s", t.Name, e),
+				Signature: "embedded",
+			})
+		}
+		for _, m := range t.Methods {
+			if t.Kind == TypeInterface {
+				symbols = append(symbols, APISymbol{
+					Kind:      APIInterfaceMethod,
+					Name:      fmt.Sprintf("%s.%s", t.Name, m.Name),
+					Signature: m.Signature,
+				})
+				continue
+			}
+			receiver := t.receiverName()
+			if m.Pointer {
+				receiver = "*" + t.receiverName()
+			}
+			symbols = append(symbols, APISymbol{
+				Kind:      APIMethod,
+				Name:      fmt.Sprintf("%s.%s", t.Name, m.Name),
+				Signature: m.Signature,
+				Receiver:  receiver,
+			})
+		}
+	}
+	for _, v := range p.exportedVariables {
+		symbols = append(symbols, APISymbol{Kind: APIVariable, Name: v.Name, Signature: v.Type})
+	}
+	for _, c := range p.exportedConstants {
+		symbols = append(symbols, APISymbol{Kind: APIConstant, Name: c.Name, Signature: c.Type + " = " + c.Value})
+	}
+	sort.Slice(symbols, func(i, j int) bool {
+		if symbols[i].Name != symbols[j].Name {
+			return symbols[i].Name < symbols[j].Name
+		}
+		return symbols[i].Kind < symbols[j].Kind
+	})
+	return symbols
+}
+
+type ChangeKind int
+
+const (
+	ChangeAdded ChangeKind = iota
+	ChangeRemoved
+	ChangeModified
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case ChangeAdded:
+		return "added"
+	case ChangeRemoved:
+		return "removed"
+	case ChangeModified:
+		return "modified"
+	}
+	return "unknown"
+}
+
+type APIChange struct {
+	Module   string
+	Package  string
+	Kind     APIKind
+	Name     string
+	Change   ChangeKind
+	Old      string
+	New      string
+	Breaking bool
+}
+
+type Bump int
+
+const (
+	BumpPatch Bump = iota
+	BumpMinor
+	BumpMajor
+)
+
+func (b Bump) String() string {
+	switch b {
+	case BumpPatch:
+		return "patch"
+	case BumpMinor:
+		return "minor"
+	case BumpMajor:
+		return "major"
+	}
+	return "unknown"
+}
+
+type ModuleVerdict struct {
+	Module     string
+	Bump       Bump
+	Breaking   int
+	Compatible int
+}
+
+type APIDiff struct {
+	Changes []APIChange
+	Modules []ModuleVerdict
+}
+
+// CompareAPI classifies the changes of the exported API from oldPackages to newPackages and
+// recommends a semantic version bump for every module. Every symbol of Package.API is compared,
+// the removed and modified symbols and the new interface methods are breaking. Test packages,
+// main packages and internal packages are not part of the API and are ignored.
+func CompareAPI(oldPackages, newPackages *Packages) *APIDiff {
+	oldPackages.mtx.RLock()
+	defer oldPackages.mtx.RUnlock()
+	newPackages.mtx.RLock()
+	defer newPackages.mtx.RUnlock()
+
+	d := &APIDiff{}
+	verdicts := map[string]*ModuleVerdict{}
+	paths := keys(oldPackages.byPath)
+	for _, pkgPath := range keys(newPackages.byPath) {
+		if oldPackages.byPath[pkgPath] == nil {
+			paths = append(paths, pkgPath)
+		}
+	}
+	sort.Strings(paths)
+	for _, pkgPath := range paths {
+		o, n := oldPackages.byPath[pkgPath], newPackages.byPath[pkgPath]
+		if o != nil && !isPublicPackage(o) {
+			o = nil
+		}
+		if n != nil && !isPublicPackage(n) {
+			n = nil
+		}
+		var module string
+		switch {
+		case n != nil:
+			module = n.module
+		case o != nil:
+			module = o.module
+		default:
+			continue
+		}
+		if verdicts[module] == nil {
+			verdicts[module] = &ModuleVerdict{Module: module}
+		}
+		for _, c := range comparePackageAPI(o, n) {
+			c.Module = module
+			c.Package = pkgPath
+			if c.Breaking {
+				verdicts[module].Breaking++
+			} else {
+				verdicts[module].Compatible++
+			}
+			d.Changes = append(d.Changes, c)
+		}
+	}
+	for _, v := range verdicts {
+		switch {
+		case v.Breaking > 0:
+			v.Bump = BumpMajor
+		case v.Compatible > 0:
+			v.Bump = BumpMinor
+		}
+		d.Modules = append(d.Modules, *v)
+	}
+	sort.Slice(d.Modules, func(i, j int) bool {
+		return d.Modules[i].Module < d.Modules[j].Module
+	})
+	return d
+}
+
+func comparePackageAPI(o, n *Package) []APIChange {
+	switch {
+	case o == nil:
+		return []APIChange{{Kind: APIPackage, Name: n.path, Change: ChangeAdded}}
+	case n == nil:
+		return []APIChange{{Kind: APIPackage, Name: o.path, Change: ChangeRemoved, Breaking: true}}
+	}
+
+	type key struct {
+		kind APIKind
+		name string
+	}
+	oldSymbols := map[key]APISymbol{}
+	for _, s := range o.API() {
+		oldSymbols[key{s.Kind, s.Name}] = s
+	}
+	var changes []APIChange
+	newSymbols := map[key]bool{}
+	for _, s := range n.API() {
+		newSymbols[key{s.Kind, s.Name}] = true
+	}
+	// The members of the added and removed types are reported by their type
+	memberOfChangedType := func(s APISymbol) bool {
+		idx := strings.Index(s.Name, ".")
+		if idx < 0 {
+			return false
+		}
+		k := key{APIType, s.Name[:idx]}
+		_, inOld := oldSymbols[k]
+		return inOld != newSymbols[k]
+	}
+	// The types of the snapshots migrated from the older versions have no members, so their
+	// members in the new snapshot are not known to be added
+	unknownMembers := map[string]bool{}
+	for _, t := range o.exportedTypes {
+		if t.Kind == TypeUnknown {
+			unknownMembers[t.Name] = true
+		}
+	}
+	for _, s := range n.API() {
+		old, ok := oldSymbols[key{s.Kind, s.Name}]
+		switch {
+		case memberOfChangedType(s):
+		case !ok && s.Kind != APIType && unknownMembers[strings.SplitN(s.Name, ".", 2)[0]]:
+		case !ok:
+			changes = append(changes, APIChange{
+				Kind:   s.Kind,
+				Name:   s.Name,
+				Change: ChangeAdded,
+				New:    s.declaration(),
+				// Every implementation of the interface breaks by a new method
+				Breaking: s.Kind == APIInterfaceMethod,
+			})
+		case old.Signature == "" || s.Signature == "":
+			// The snapshots migrated from the older versions have no types for the types and the variables
+		case !sameSignature(old, s) || old.Receiver != s.Receiver || old.Tag != s.Tag:
+			changes = append(changes, APIChange{
+				Kind:   s.Kind,
+				Name:   s.Name,
+				Change: ChangeModified,
+				Old:    old.declaration(),
+				New:    s.declaration(),
+				// Changing a pointer receiver to a value receiver only extends the method set, and
+				// the struct tags do not affect the compilation of the importers
+				Breaking: !sameSignature(old, s) || (old.Receiver != s.Receiver && old.Receiver != "*"+s.Receiver),
+			})
+		}
+	}
+	for _, s := range o.API() {
+		if !newSymbols[key{s.Kind, s.Name}] && !memberOfChangedType(s) {
+			changes = append(changes, APIChange{
+				Kind:     s.Kind,
+				Name:     s.Name,
+				Change:   ChangeRemoved,
+				Old:      s.declaration(),
+				Breaking: true,
+			})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Name != changes[j].Name {
+			return changes[i].Name < changes[j].Name
+		}
+		return changes[i].Kind < changes[j].Kind
+	})
+	return changes
+}
+
+// sameSignature returns true if the signatures of the symbols only differ by the names of the
+// parameters and the results, which do not affect the callers.
+func sameSignature(a, b APISymbol) bool {
+	return a.Signature == b.Signature || unnamedSignature(a) == unnamedSignature(b)
+}
+
+// unnamedSignature returns the signature of the symbol without the names of the parameters and
+// the results of every function type in it. The signatures which could not be parsed, like the
+// kinds of the structs or the values of the constants, are returned as they are.
+func unnamedSignature(s APISymbol) string {
+	var src string
+	switch s.Kind {
+	case APIFunc:
+		src = "package p\nfunc " + s.Signature
+	case APIMethod, APIInterfaceMethod:
+		src = "package p\ntype _ func" + s.Signature
+	default:
+		src = "package p\ntype _ " + s.Signature
+	}
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", src, 0)
+	if err != nil || len(f.Decls) != 1 {
+		return s.Signature
+	}
+	ast.Inspect(f, func(n ast.Node) bool {
+		if fn, ok := n.(*ast.FuncType); ok {
+			unnamedFields(fn.Params)
+			unnamedFields(fn.Results)
+		}
+		return true
+	})
+	buf := &bytes.Buffer{}
+	if err := printer.Fprint(buf, fset, f.Decls[0]); err != nil {
+		return s.Signature
+	}
+	return buf.String()
+}
+
+// unnamedFields replaces the named fields with a field of the same type for every name.
+func unnamedFields(fields *ast.FieldList) {
+	if fields == nil {
+		return
+	}
+	var res []*ast.Field
+	for _, f := range fields.List {
+		for range max(len(f.Names), 1) {
+			res = append(res, &ast.Field{Type: f.Type})
+		}
+	}
+	fields.List = res
+}
+
+// isPublicPackage returns true if the package could be imported by the other modules.
+func isPublicPackage(p *Package) bool {
+	if p.forTest != "" || p.name == "main" {
+		return false
+	}
+	for _, part := range strings.Split(p.path, "/") {
+		if part == "internal" {
+			return false
+		}
+	}
+	return true
+}
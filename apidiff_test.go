@@ -0,0 +1,192 @@
+package godeep
+
+import (
+	"fmt"
+	"testing"
+)
+
+// apiSnapshot returns the packages of a snapshot with the package example.com/a/b of the module
+// example.com/a.
+func apiSnapshot(t *testing.T, pkg string) *Packages {
+	t.Helper()
+	a := InitPackages()
+	data := fmt.Sprintf(`{"version": %d, "packages": [{"name": "b", "path": "example.com/a/b", "module": "example.com/a", %s}]}`, SnapshotVersion, pkg)
+	if err := a.Unmarshal([]byte(data)); err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func TestCompareAPI(t *testing.T) {
+	tests := []struct {
+		name      string
+		old       string
+		new       string
+		change    ChangeKind
+		kind      APIKind
+		breaking  bool
+		unchanged bool
+	}{
+		{
+			name:     "removed func",
+			old:      `"exportedFunctions": ["New() *Client", "Close()"]`,
+			new:      `"exportedFunctions": ["New() *Client"]`,
+			change:   ChangeRemoved,
+			kind:     APIFunc,
+			breaking: true,
+		},
+		{
+			name:     "changed func signature",
+			old:      `"exportedFunctions": ["New() *Client"]`,
+			new:      `"exportedFunctions": ["New(opts ...Option) (*Client, error)"]`,
+			change:   ChangeModified,
+			kind:     APIFunc,
+			breaking: true,
+		},
+		{
+			name:      "renamed func parameter",
+			old:       `"exportedFunctions": ["Open(name string) (*File, error)"]`,
+			new:       `"exportedFunctions": ["Open(path string) (f *File, err error)"]`,
+			unchanged: true,
+		},
+		{
+			name:      "regrouped func parameters",
+			old:       `"exportedFunctions": ["Copy(dst, src []byte, f func(n int)) int"]`,
+			new:       `"exportedFunctions": ["Copy(to []byte, from []byte, f func(int)) (n int)"]`,
+			unchanged: true,
+		},
+		{
+			name:      "renamed method parameter",
+			old:       `"exportedTypes": [{"name": "C", "kind": "struct", "methods": [{"name": "Do", "signature": "(name string)"}]}]`,
+			new:       `"exportedTypes": [{"name": "C", "kind": "struct", "methods": [{"name": "Do", "signature": "(path string)"}]}]`,
+			unchanged: true,
+		},
+		{
+			name:     "added method",
+			old:      `"exportedTypes": [{"name": "Client", "kind": "struct"}]`,
+			new:      `"exportedTypes": [{"name": "Client", "kind": "struct", "methods": [{"name": "Do", "signature": "() error", "pointer": true}]}]`,
+			change:   ChangeAdded,
+			kind:     APIMethod,
+			breaking: false,
+		},
+		{
+			name:     "removed method",
+			old:      `"exportedTypes": [{"name": "Client", "kind": "struct", "methods": [{"name": "Do", "signature": "() error"}]}]`,
+			new:      `"exportedTypes": [{"name": "Client", "kind": "struct"}]`,
+			change:   ChangeRemoved,
+			kind:     APIMethod,
+			breaking: true,
+		},
+		{
+			name:     "value receiver to pointer receiver",
+			old:      `"exportedTypes": [{"name": "Client", "kind": "struct", "methods": [{"name": "Do", "signature": "() error"}]}]`,
+			new:      `"exportedTypes": [{"name": "Client", "kind": "struct", "methods": [{"name": "Do", "signature": "() error", "pointer": true}]}]`,
+			change:   ChangeModified,
+			kind:     APIMethod,
+			breaking: true,
+		},
+		{
+			name:     "pointer receiver to value receiver",
+			old:      `"exportedTypes": [{"name": "Client", "kind": "struct", "methods": [{"name": "Do", "signature": "() error", "pointer": true}]}]`,
+			new:      `"exportedTypes": [{"name": "Client", "kind": "struct", "methods": [{"name": "Do", "signature": "() error"}]}]`,
+			change:   ChangeModified,
+			kind:     APIMethod,
+			breaking: false,
+		},
+		{
+			name:     "added field",
+			old:      `"exportedTypes": [{"name": "Client", "kind": "struct"}]`,
+			new:      `"exportedTypes": [{"name": "Client", "kind": "struct", "fields": [{"name": "Timeout", "type": "int"}]}]`,
+			change:   ChangeAdded,
+			kind:     APIField,
+			breaking: false,
+		},
+		{
+			name:     "changed field type",
+			old:      `"exportedTypes": [{"name": "Client", "kind": "struct", "fields": [{"name": "Timeout", "type": "int"}]}]`,
+			new:      `"exportedTypes": [{"name": "Client", "kind": "struct", "fields": [{"name": "Timeout", "type": "time.Duration"}]}]`,
+			change:   ChangeModified,
+			kind:     APIField,
+			breaking: true,
+		},
+		{
+			name:     "changed field tag",
+			old:      `"exportedTypes": [{"name": "Client", "kind": "struct", "fields": [{"name": "X", "type": "int", "tag": "json:\"x\""}]}]`,
+			new:      `"exportedTypes": [{"name": "Client", "kind": "struct", "fields": [{"name": "X", "type": "int", "tag": "json:\"y\""}]}]`,
+			change:   ChangeModified,
+			kind:     APIField,
+			breaking: false,
+		},
+		{
+			name:     "added interface method",
+			old:      `"exportedTypes": [{"name": "Store", "kind": "interface", "methods": [{"name": "Get", "signature": "(key string) []byte"}]}]`,
+			new:      `"exportedTypes": [{"name": "Store", "kind": "interface", "methods": [{"name": "Get", "signature": "(key string) []byte"}, {"name": "Put", "signature": "(key string, v []byte)"}]}]`,
+			change:   ChangeAdded,
+			kind:     APIInterfaceMethod,
+			breaking: true,
+		},
+		{
+			name:     "changed type kind",
+			old:      `"exportedTypes": [{"name": "Mode", "kind": "basic", "underlying": "int"}]`,
+			new:      `"exportedTypes": [{"name": "Mode", "kind": "basic", "underlying": "string"}]`,
+			change:   ChangeModified,
+			kind:     APIType,
+			breaking: true,
+		},
+		{
+			name:     "added const",
+			old:      `"exportedConstants": [{"name": "ModeFast", "type": "Mode", "value": "0"}]`,
+			new:      `"exportedConstants": [{"name": "ModeFast", "type": "Mode", "value": "0"}, {"name": "ModeSafe", "type": "Mode", "value": "1"}]`,
+			change:   ChangeAdded,
+			kind:     APIConstant,
+			breaking: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := CompareAPI(apiSnapshot(t, tt.old), apiSnapshot(t, tt.new))
+			if tt.unchanged {
+				if len(d.Changes) != 0 || len(d.Modules) != 1 || d.Modules[0].Bump != BumpPatch {
+					t.Errorf("expected no changes and a patch bump, got %v %v", d.Changes, d.Modules)
+				}
+				return
+			}
+			if len(d.Changes) != 1 {
+				t.Fatalf("expected a single change, got %v", d.Changes)
+			}
+			c := d.Changes[0]
+			if c.Change != tt.change || c.Kind != tt.kind || c.Breaking != tt.breaking {
+				t.Errorf("expected %s %s (breaking: %v), got %s %s (breaking: %v)",
+					tt.change, tt.kind, tt.breaking, c.Change, c.Kind, c.Breaking)
+			}
+			bump := BumpMinor
+			if tt.breaking {
+				bump = BumpMajor
+			}
+			if len(d.Modules) != 1 || d.Modules[0].Bump != bump {
+				t.Errorf("expected a %s bump, got %v", bump, d.Modules)
+			}
+		})
+	}
+}
+
+func TestCompareAPIMigratedSnapshot(t *testing.T) {
+	// The documents written by the json template only had the names of the types, their members
+	// are not known
+	oldPackages := InitPackages()
+	err := oldPackages.Unmarshal([]byte(`{"packages": [{"name": "b", "path": "example.com/a/b", "exported_types": ["Client", "Store"]}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	newPackages := apiSnapshot(t, `"exportedTypes": [
+		{"name": "Client", "kind": "struct", "methods": [{"name": "Do", "signature": "() error"}]},
+		{"name": "Store", "kind": "interface", "methods": [{"name": "Get", "signature": "(key string) []byte"}]}
+	]`)
+	d := CompareAPI(oldPackages, newPackages)
+	if len(d.Changes) != 0 {
+		t.Errorf("expected no changes, got %v", d.Changes)
+	}
+	if len(d.Modules) != 1 || d.Modules[0].Bump != BumpPatch {
+		t.Errorf("expected a patch bump, got %v", d.Modules)
+	}
+}
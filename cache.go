@@ -0,0 +1,291 @@
+package godeep
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"golang.org/x/tools/go/packages"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// cacheVersion must be increased whenever the cached entries could not be read by the older
+// versions, the snapshot version is part of the keys too.
+const cacheVersion = 2
+
+// DefaultCacheDir returns the directory of the analysis cache under the user cache directory.
+func DefaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "godeep")
+}
+
+// UseCache makes FindPackages keep the analyzed packages in the cache dir and only load the
+// packages which are changed since the last analysis, with the packages which depend on them.
+// An empty dir disables the cache. The packages restored from the cache have no type
+// information, see HasTypes.
+func (a *Packages) UseCache(dir string) {
+	a.mtx.Lock()
+	a.cacheDir = dir
+	a.mtx.Unlock()
+}
+
+type cacheEntry struct {
+	Key        string          `json:"key"`
+	Package    SnapshotPackage `json:"package"`
+	References []Reference     `json:"references,omitempty"`
+}
+
+type cacheFile struct {
+	Version  int                   `json:"version"`
+	Packages map[string]cacheEntry `json:"packages"`
+}
+
+// analysisCache is the cache of one root path and patterns. The key of every package is the hash
+// of the build configuration, the go.mod, go.sum and go.work files, its own files and the files
+// of all the packages which it imports, directly or indirectly, except the standard library. The
+// dependencies in the module cache never change, so only their module versions are hashed.
+type analysisCache struct {
+	path     string
+	config   string
+	modCache string
+	entries  map[string]cacheEntry
+	keys     map[string]string
+}
+
+// openCache reads the cache of the rootPath and the patterns, a missing or a broken cache file is
+// an empty cache.
+func openCache(dir, rootPath string, patterns []string, moduleDirs []string) *analysisCache {
+	h := sha256.New()
+	fmt.Fprintln(h, cacheVersion, SnapshotVersion, runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	for _, env := range []string{"GOOS", "GOARCH", "CGO_ENABLED", "GOFLAGS", "GOEXPERIMENT", "GOWORK"} {
+		fmt.Fprintln(h, env, os.Getenv(env))
+	}
+	fmt.Fprintln(h, strings.Join(patterns, " "))
+	var modFiles []string
+	if goWork := findWorkspace(rootPath); goWork != "" {
+		modFiles = append(modFiles, goWork, goWork+".sum")
+	}
+	for _, moduleDir := range moduleDirs {
+		modFiles = append(modFiles, filepath.Join(moduleDir, "go.mod"), filepath.Join(moduleDir, "go.sum"))
+	}
+	sort.Strings(modFiles)
+	for _, f := range modFiles {
+		hashFile(h, f)
+	}
+
+	// Every root path and patterns has its own cache file, so analyzing a subset of the packages
+	// does not drop the others
+	root := sha256.Sum256([]byte(rootPath + "\n" + strings.Join(patterns, " ")))
+	c := &analysisCache{
+		path:     filepath.Join(dir, hex.EncodeToString(root[:8])+".json"),
+		config:   hex.EncodeToString(h.Sum(nil)),
+		modCache: goModCache(rootPath),
+		entries:  map[string]cacheEntry{},
+		keys:     map[string]string{},
+	}
+	data, err := ioutil.ReadFile(c.path)
+	if err != nil {
+		return c
+	}
+	cf := cacheFile{}
+	if err := json.Unmarshal(data, &cf); err == nil && cf.Version == cacheVersion {
+		c.entries = cf.Packages
+	}
+	return c
+}
+
+// hashKeys computes the keys of the listed packages and their dependencies, the test variants and
+// the external test packages are listed too, so the files and the imports of the tests are part
+// of the keys.
+func (c *analysisCache) hashKeys(listed []*packages.Package) {
+	files := map[string][]string{}
+	versions := map[string]string{}
+	imports := map[string]map[string]bool{}
+	packages.Visit(listed, nil, func(pkg *packages.Package) {
+		if isStdPackage(pkg) || strings.HasSuffix(pkg.ID, ".test") {
+			return
+		}
+		if version := c.moduleCacheVersion(pkg.Module); version != "" {
+			versions[pkg.PkgPath] = version
+		} else {
+			files[pkg.PkgPath] = append(files[pkg.PkgPath], pkg.GoFiles...)
+			files[pkg.PkgPath] = append(files[pkg.PkgPath], pkg.OtherFiles...)
+		}
+		if imports[pkg.PkgPath] == nil {
+			imports[pkg.PkgPath] = map[string]bool{}
+		}
+		for _, ipkg := range pkg.Imports {
+			// The imports of the test variants have their variant in the ID, i.e. 'a/b [a/c.test]'
+			imports[pkg.PkgPath][strings.SplitN(ipkg.ID, " ", 2)[0]] = true
+		}
+		// A package which could not be listed is never restored from the cache
+		if len(pkg.Errors) > 0 {
+			files[pkg.PkgPath] = append(files[pkg.PkgPath], "")
+		}
+	})
+
+	own := make(map[string]string, len(imports))
+	for pkgPath := range imports {
+		pkgFiles := files[pkgPath]
+		h := sha256.New()
+		fmt.Fprintln(h, c.config, pkgPath, versions[pkgPath])
+		sort.Strings(pkgFiles)
+		for idx, f := range pkgFiles {
+			if idx > 0 && pkgFiles[idx-1] == f {
+				continue
+			}
+			if !hashFile(h, f) {
+				own[pkgPath] = ""
+				break
+			}
+		}
+		if _, broken := own[pkgPath]; !broken {
+			own[pkgPath] = hex.EncodeToString(h.Sum(nil))
+		}
+	}
+
+	for _, pkg := range listed {
+		pkgPath := pkg.PkgPath
+		if own[pkgPath] == "" || c.keys[pkgPath] != "" {
+			continue
+		}
+		// The dependencies are visited without any order, and the cycles of the tests are
+		// visited once
+		deps := map[string]bool{}
+		queue := []string{pkgPath}
+		for len(queue) > 0 {
+			for dep := range imports[queue[0]] {
+				if _, known := imports[dep]; known && !deps[dep] {
+					deps[dep] = true
+					queue = append(queue, dep)
+				}
+			}
+			queue = queue[1:]
+		}
+		h := sha256.New()
+		fmt.Fprintln(h, own[pkgPath])
+		broken := false
+		for _, dep := range sortedKeys(deps) {
+			broken = broken || own[dep] == ""
+			fmt.Fprintln(h, dep, own[dep])
+		}
+		if !broken {
+			c.keys[pkgPath] = hex.EncodeToString(h.Sum(nil))
+		}
+	}
+}
+
+// moduleCacheVersion returns the path and the version of the module if it is in the module cache,
+// the replaced modules and the vendored packages could be edited and must be hashed by their files.
+func (c *analysisCache) moduleCacheVersion(m *packages.Module) string {
+	if m == nil || c.modCache == "" {
+		return ""
+	}
+	dir := m.Dir
+	if m.Replace != nil {
+		m, dir = m.Replace, m.Replace.Dir
+	}
+	if dir == "" || m.Version == "" || !hasPathPrefix(filepath.ToSlash(dir), filepath.ToSlash(c.modCache)) {
+		return ""
+	}
+	return m.Path + "@" + m.Version
+}
+
+// goModCache returns the module cache directory of the go command, or an empty string if it is
+// not known.
+func goModCache(dir string) string {
+	cmd := exec.Command("go", "env", "GOMODCACHE")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// restore returns the cached package if its key is not changed.
+func (c *analysisCache) restore(pkgPath string) (*Package, bool) {
+	key, ok := c.keys[pkgPath]
+	if !ok {
+		return nil, false
+	}
+	e, ok := c.entries[pkgPath]
+	if !ok || e.Key != key {
+		return nil, false
+	}
+	p := newPackage(e.Package)
+	// The importers are collected again after the analysis
+	p.importedByPackages = nil
+	p.references = e.References
+	return p, true
+}
+
+// save writes the packages which have a key, the packages of the older analysis which are not
+// analyzed anymore are removed.
+func (c *analysisCache) save(a *Packages) error {
+	cf := cacheFile{
+		Version:  cacheVersion,
+		Packages: map[string]cacheEntry{},
+	}
+	a.mtx.RLock()
+	for pkgPath, p := range a.byPath {
+		key, ok := c.keys[pkgPath]
+		if !ok {
+			continue
+		}
+		sp := newSnapshotPackage(p)
+		sp.ImportedBy = nil
+		cf.Packages[pkgPath] = cacheEntry{
+			Key:        key,
+			Package:    sp,
+			References: p.references,
+		}
+	}
+	a.mtx.RUnlock()
+	data, err := json.Marshal(cf)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return err
+	}
+	// The file is replaced at once, so a concurrent analysis never reads a partial cache
+	tmp := fmt.Sprintf("%s.%d", c.path, os.Getpid())
+	if err := ioutil.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, c.path)
+}
+
+// hashFile writes the name and the content of the file into the hash, it returns false if the
+// file could not be read. The missing go.sum and go.work files are hashed as missing.
+func hashFile(h io.Writer, path string) bool {
+	fmt.Fprintln(h, path)
+	f, err := os.Open(path)
+	if err != nil {
+		fmt.Fprintln(h, "missing")
+		return os.IsNotExist(err) && path != ""
+	}
+	defer f.Close()
+	_, err = io.Copy(h, f)
+	return err == nil
+}
+
+func sortedKeys(m map[string]bool) []string {
+	res := make([]string, 0, len(m))
+	for k := range m {
+		res = append(res, k)
+	}
+	sort.Strings(res)
+	return res
+}
@@ -0,0 +1,105 @@
+package godeep
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func TestCacheReloadsChangedPackages(t *testing.T) {
+	root := writeModule(t, map[string]string{
+		"main/go.mod": `module example.com/main
+
+go 1.22
+
+require example.com/lib v0.0.0
+
+replace example.com/lib => ../lib
+`,
+		"main/a/a.go": `package a
+
+import "example.com/lib/log"
+
+var Logger = log.New()
+`,
+		"main/b/b.go":    "package b\n\nconst Name = \"b\"\n",
+		"lib/go.mod":     "module example.com/lib\n\ngo 1.22\n",
+		"lib/log/log.go": "package log\n\ntype Logger struct{}\n\nfunc New() *Logger { return &Logger{} }\n",
+	})
+	dir := filepath.Join(root, "main")
+	cacheDir := t.TempDir()
+	// analyze returns the loaded packages, the other ones are restored from the cache
+	analyze := func() (*Packages, []string) {
+		t.Helper()
+		var (
+			mtx    sync.Mutex
+			loaded []string
+		)
+		a := InitPackages()
+		a.UseCache(cacheDir)
+		err := FindPackages(a, dir, func(pkgPath string) {
+			mtx.Lock()
+			loaded = append(loaded, pkgPath)
+			mtx.Unlock()
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		sort.Strings(loaded)
+		return a, loaded
+	}
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		change func()
+		loaded []string
+	}{
+		{
+			name:   "empty cache",
+			change: func() {},
+			loaded: []string{"example.com/main/a", "example.com/main/b"},
+		},
+		{
+			name:   "no change",
+			change: func() {},
+		},
+		{
+			name: "changed package",
+			change: func() {
+				write("main/b/b.go", "package b\n\nconst Name = \"B\"\n")
+			},
+			loaded: []string{"example.com/main/b"},
+		},
+		{
+			name: "changed dependency",
+			change: func() {
+				write("lib/log/log.go", "package log\n\ntype Logger struct{}\n")
+			},
+			loaded: []string{"example.com/main/a"},
+		},
+	}
+	var a *Packages
+	for _, tt := range tests {
+		tt.change()
+		var loaded []string
+		a, loaded = analyze()
+		if !reflect.DeepEqual(loaded, tt.loaded) {
+			t.Errorf("%s: expected %v to be loaded, got %v", tt.name, tt.loaded, loaded)
+		}
+		if a.Len() != 2 {
+			t.Errorf("%s: expected 2 packages, got %d", tt.name, a.Len())
+		}
+	}
+	if p := a.GetByPath("example.com/main/a"); p == nil || len(p.Errors()) == 0 {
+		t.Error("expected example.com/main/a to report the removed log.New")
+	}
+}
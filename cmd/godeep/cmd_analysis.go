@@ -0,0 +1,146 @@
+package main
+
+import (
+	"fmt"
+	"github.com/fatih/color"
+	"github.com/ronaksoft/godeep"
+	"github.com/spf13/cobra"
+	"strings"
+)
+
+func init() {
+	CmdAnalyze.AddCommand(CmdCycles)
+	RootCmd.AddCommand(CmdWhy, CmdImpact)
+	CmdWhy.Flags().Int(FlagAlternatives, 3, "number of alternative import chains to print")
+	CmdImpact.Flags().Bool(FlagDirect, false, "only print the direct importers")
+	CmdImpact.Flags().Int(FlagTop, 20, "number of packages to print when ranking")
+}
+
+// EnsureAnalyzed runs the analyze command if nothing has been analyzed or imported yet, so the
+// query commands could be used directly from the shell.
+func EnsureAnalyzed() {
+	if AllPackages.Len() == 0 {
+		CmdAnalyze.Run(CmdAnalyze, nil)
+	}
+}
+
+// EnsureTypes analyzes the packages without the cache, if they are not analyzed yet or some of
+// them are restored from the cache and have no type information.
+func EnsureTypes() {
+	if AllPackages.Len() == 0 || (restoredFromCache && !AllPackages.HasTypes()) {
+		PrintOnErr(RootCmd.PersistentFlags().Set(FlagNoCache, "true"))
+		CmdAnalyze.Run(CmdAnalyze, nil)
+		PrintOnErr(RootCmd.PersistentFlags().Set(FlagNoCache, "false"))
+	}
+}
+
+var CmdCycles = &cobra.Command{
+	Use:   "cycles",
+	Short: "prints the import cycles, test cycles and the cycles between directories and modules",
+	Run: func(cmd *cobra.Command, args []string) {
+		EnsureAnalyzed()
+		cycles := AllPackages.Cycles()
+		printers := map[godeep.CycleKind]func(format string, a ...interface{}){
+			godeep.CycleImport: color.Red,
+			godeep.CycleTest:   color.HiYellow,
+			godeep.CycleGroup:  color.HiBlue,
+			godeep.CycleModule: color.HiMagenta,
+		}
+		for _, kind := range []godeep.CycleKind{godeep.CycleImport, godeep.CycleTest, godeep.CycleGroup, godeep.CycleModule} {
+			var filtered []godeep.Cycle
+			for _, c := range cycles {
+				if c.Kind == kind {
+					filtered = append(filtered, c)
+				}
+			}
+			printer := printers[kind]
+			printer("Cycles (%s): (%d)", kind, len(filtered))
+			for idx, c := range filtered {
+				printer("\t %d. %s", idx+1, strings.Join(c.Path, " -> "))
+				if len(c.Members) > len(c.Path)-1 {
+					fmt.Println(fmt.Sprintf("\t    members: %s", strings.Join(c.Members, ", ")))
+				}
+			}
+		}
+	},
+}
+
+var CmdWhy = &cobra.Command{
+	Use:   "why <from> <to>",
+	Short: "prints the shortest import chains which make 'from' depend on 'to'",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		alternatives, err := cmd.Flags().GetInt(FlagAlternatives)
+		PrintOnErr(err)
+
+		EnsureAnalyzed()
+		paths := AllPackages.Paths(args[0], args[1], alternatives)
+		if len(paths) == 0 {
+			fmt.Println(fmt.Sprintf("'%s' does not depend on '%s'", args[0], args[1]))
+			return
+		}
+		for idx, path := range paths {
+			if idx == 0 {
+				color.HiGreen("Shortest Path: (%d imports)", len(path))
+			} else {
+				color.HiBlue("Alternative %d: (%d imports)", idx, len(path))
+			}
+			fmt.Println(fmt.Sprintf("\t %s", path[0].From))
+			for _, step := range path {
+				if len(step.Positions) == 0 {
+					// The imports of the third-party packages have no positions
+					fmt.Println(fmt.Sprintf("\t  -> %s", step.To))
+					continue
+				}
+				pos := fmt.Sprintf("%s:%d", step.Positions[0].Filename, step.Positions[0].Line)
+				fmt.Println(fmt.Sprintf("\t  -> %s %s", step.To, color.HiBlackString("(%s)", pos)))
+			}
+		}
+	},
+}
+
+var CmdImpact = &cobra.Command{
+	Use:   "impact [pkg]",
+	Short: "prints the packages affected by a change in pkg, or ranks the packages by their impact",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		direct, err := cmd.Flags().GetBool(FlagDirect)
+		PrintOnErr(err)
+		top, err := cmd.Flags().GetInt(FlagTop)
+		PrintOnErr(err)
+
+		EnsureAnalyzed()
+		if len(args) == 0 {
+			printImpactRanking(top)
+			return
+		}
+		dependents := AllPackages.Dependents(args[0], !direct)
+		counts := map[godeep.DependentKind]int{}
+		for _, d := range dependents {
+			counts[d.Kind]++
+		}
+		color.Green("========== %s ========", args[0])
+		color.HiGreen("Affected: %d (packages: %d, mains: %d, tests: %d)",
+			len(dependents), counts[godeep.DependentPackage], counts[godeep.DependentMain], counts[godeep.DependentTest],
+		)
+		distance := 0
+		for _, d := range dependents {
+			if d.Distance != distance {
+				distance = d.Distance
+				color.HiBlue("Distance %d:", distance)
+			}
+			fmt.Println(fmt.Sprintf("\t %s %s", d.Path, color.HiBlackString("(%s)", d.Kind)))
+		}
+	},
+}
+
+func printImpactRanking(top int) {
+	impacts := AllPackages.Impacts()
+	if top > 0 && len(impacts) > top {
+		impacts = impacts[:top]
+	}
+	color.HiGreen("Riskiest Packages: (%d)", len(impacts))
+	for idx, i := range impacts {
+		color.HiBlue("\t %d. %s (%d)", idx+1, i.Path, i.Count)
+	}
+}
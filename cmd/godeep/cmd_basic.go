@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sync/atomic"
 )
 
 /*
@@ -20,7 +21,53 @@ import (
 */
 
 func init() {
+	CmdPrint.Run = printPackages
 	RootCmd.AddCommand(CmdAnalyze, CmdPrint, CmdImport, CmdExport, CmdExit)
+	fs := CmdExport.Flags()
+	fs.String(FlagFormat, "json", "output format: json, dot, mermaid, plantuml, html")
+	fs.String(FlagCluster, "none", "cluster the diagram nodes by: none, module, prefix")
+	fs.Int(FlagPrefixDepth, 3, "number of path elements used by the prefix clustering")
+	fs.Bool(FlagStd, true, "include the standard library packages in the diagram")
+	fs.String(FlagFocus, "", "only include the packages around this package in the diagram")
+	fs.Int(FlagDepth, 1, "max distance of the included packages from the focused package, 0 means no limit")
+	fs.Bool(FlagModules, false, "draw the module graph instead of the package graph")
+	CmdPrint.Flags().Bool(FlagModules, false, "print the module graph instead of the packages")
+}
+
+func diagramOptions(cmd *cobra.Command) (godeep.DiagramOptions, error) {
+	opt := godeep.DiagramOptions{}
+	cluster, err := cmd.Flags().GetString(FlagCluster)
+	if err != nil {
+		return opt, err
+	}
+	switch cluster {
+	case "none":
+		opt.Cluster = godeep.ClusterNone
+	case "module":
+		opt.Cluster = godeep.ClusterModule
+	case "prefix":
+		opt.Cluster = godeep.ClusterPrefix
+	default:
+		return opt, fmt.Errorf("unsupported cluster: %s", cluster)
+	}
+	opt.PrefixDepth, err = cmd.Flags().GetInt(FlagPrefixDepth)
+	if err != nil {
+		return opt, err
+	}
+	opt.Std, err = cmd.Flags().GetBool(FlagStd)
+	if err != nil {
+		return opt, err
+	}
+	opt.Focus, err = cmd.Flags().GetString(FlagFocus)
+	if err != nil {
+		return opt, err
+	}
+	opt.Depth, err = cmd.Flags().GetInt(FlagDepth)
+	if err != nil {
+		return opt, err
+	}
+	opt.Modules, err = cmd.Flags().GetBool(FlagModules)
+	return opt, err
 }
 
 func ResetCommands() {
@@ -57,13 +104,40 @@ var CmdImport = &cobra.Command{
 
 var CmdExport = &cobra.Command{
 	Use:   "export",
-	Short: "exports the analyzed data as a json file",
+	Short: "exports the analyzed data as a json file or as a diagram",
 	Run: func(cmd *cobra.Command, args []string) {
 		outputDir, err := cmd.Flags().GetString(FlagOutputDir)
 		PrintOnErr(err)
+		format, err := cmd.Flags().GetString(FlagFormat)
+		PrintOnErr(err)
+		opt, err := diagramOptions(cmd)
+		PanicOnErr(err)
 
-		exportData := AllPackages.Marshal()
-		f, err := os.Create(filepath.Join(outputDir, "all_packages.json"))
+		EnsureAnalyzed()
+		var exportData []byte
+		switch format {
+		case "json":
+			exportData = AllPackages.Marshal()
+		case "dot":
+			exportData = AllPackages.DOT(opt)
+		case "mermaid":
+			exportData = AllPackages.Mermaid(opt)
+		case "plantuml":
+			exportData = AllPackages.PlantUML(opt)
+		case "html":
+			exportData, err = AllPackages.HTML(opt)
+			PanicOnErr(err)
+		default:
+			PanicOnErr(fmt.Errorf("unsupported format: %s", format))
+		}
+		ext := format
+		switch format {
+		case "mermaid":
+			ext = "mmd"
+		case "plantuml":
+			ext = "puml"
+		}
+		f, err := os.Create(filepath.Join(outputDir, fmt.Sprintf("all_packages.%s", ext)))
 		PanicOnErr(err)
 		_, err = f.Write(exportData)
 		PanicOnErr(err)
@@ -72,42 +146,79 @@ var CmdExport = &cobra.Command{
 	},
 }
 
+// restoredFromCache is set if the last analysis restored some packages from the cache, which have
+// no type information.
+var restoredFromCache bool
+
 var CmdAnalyze = &cobra.Command{
 	Use: "analyze",
 	Run: func(cmd *cobra.Command, args []string) {
 		fmt.Println("Please be patient, this may take a bit longer than you think ...")
 		cwd, _ := os.Getwd()
-		err := godeep.FindPackages(AllPackages, cwd,
+		patterns, err := RootCmd.PersistentFlags().GetStringSlice(FlagPatterns)
+		PrintOnErr(err)
+		noCache, err := RootCmd.PersistentFlags().GetBool(FlagNoCache)
+		PrintOnErr(err)
+		if noCache {
+			AllPackages.UseCache("")
+		} else {
+			AllPackages.UseCache(godeep.DefaultCacheDir())
+		}
+		var analyzed int32
+		err = godeep.FindPackages(AllPackages, cwd,
 			func(path string) {
+				atomic.AddInt32(&analyzed, 1)
 				fmt.Println(fmt.Sprintf("Package '%s' %s",
 					color.WhiteString("%s", path),
 					color.GreenString("analyzed"),
 				))
 			},
+			patterns...,
 		)
 		PanicOnErr(err)
+		cached := AllPackages.Len() - int(analyzed)
+		if cached > 0 {
+			color.HiGreen("%d unchanged packages have been restored from the cache", cached)
+		}
+		restoredFromCache = cached > 0
 		color.HiGreen("All Packages have been traversed, now we are building the relation")
+		if diagnostics := AllPackages.Diagnostics(); len(diagnostics) > 0 {
+			strict, err := RootCmd.PersistentFlags().GetBool(FlagStrict)
+			PrintOnErr(err)
+			if strict {
+				printDiagnostics(diagnostics)
+				ExitOnFailure("Analysis failed in the strict mode")
+			} else {
+				color.HiYellow("Some packages have errors, run 'diagnostics' to see them")
+			}
+		}
 		ResetCommands()
 	},
 }
 
 var CmdPrint = &cobra.Command{
 	Use: "print",
-	Run: func(cmd *cobra.Command, args []string) {
-		if len(args) > 0 {
-			pkg := AllPackages.GetByPath(args[0])
-			if pkg != nil {
-				pkg.Print()
-			} else {
-				fmt.Println("Package not found:", args[0])
-			}
+}
+
+// printPackages is set in init, since it runs the analysis which resets the print sub commands.
+func printPackages(cmd *cobra.Command, args []string) {
+	EnsureAnalyzed()
+	modules, err := cmd.Flags().GetBool(FlagModules)
+	PrintOnErr(err)
+	if modules {
+		AllPackages.PrintModules()
+	} else if len(args) > 0 {
+		pkg := AllPackages.GetByPath(args[0])
+		if pkg != nil {
+			pkg.Print()
 		} else {
-			AllPackages.ForEach(func(pkgPath string, pkg *godeep.Package) {
-				pkg.Print()
-			})
+			fmt.Println("Package not found:", args[0])
 		}
-
-	},
+	} else {
+		AllPackages.ForEach(func(pkgPath string, pkg *godeep.Package) {
+			pkg.Print()
+		})
+	}
 }
 
 var CmdExit = &cobra.Command{
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"fmt"
+	"github.com/fatih/color"
+	"github.com/ronaksoft/godeep"
+	"github.com/spf13/cobra"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+func init() {
+	RootCmd.AddCommand(CmdCheck, CmdAffected, CmdDiagnostics)
+	CmdCheck.Flags().String(FlagConfig, ".godeep.yaml", "the architecture rules file")
+	CmdAffected.Flags().String(FlagRevision, "", "git revision range to read the changed files from, e.g. main...HEAD")
+}
+
+// ExitOnFailure exits with a non-zero code so CI could gate on the command, in the interactive
+// mode it only prints the reason.
+func ExitOnFailure(format string, a ...interface{}) {
+	color.Red(format, a...)
+	if !Interactive {
+		os.Exit(1)
+	}
+}
+
+var CmdCheck = &cobra.Command{
+	Use:   "check",
+	Short: "checks the import edges against the architecture rules",
+	Run: func(cmd *cobra.Command, args []string) {
+		config, err := cmd.Flags().GetString(FlagConfig)
+		PrintOnErr(err)
+
+		rules, err := godeep.LoadRules(config)
+		PanicOnErr(err)
+		EnsureAnalyzed()
+		violations := AllPackages.Check(rules)
+		for idx, v := range violations {
+			pos := ""
+			if len(v.Positions) > 0 {
+				pos = fmt.Sprintf("%s:%d: ", v.Positions[0].Filename, v.Positions[0].Line)
+			}
+			fmt.Println(fmt.Sprintf("\t %d. %s%s imports %s %s",
+				idx+1, pos, v.From, v.To, color.HiBlackString("(%s)", v.Rule),
+			))
+		}
+		if len(violations) > 0 {
+			ExitOnFailure("Rules violated: (%d)", len(violations))
+			return
+		}
+		color.HiGreen("All the rules are satisfied")
+	},
+}
+
+var CmdDiagnostics = &cobra.Command{
+	Use:   "diagnostics",
+	Short: "prints the load, parse and type errors of the analyzed packages",
+	Run: func(cmd *cobra.Command, args []string) {
+		EnsureAnalyzed()
+		diagnostics := AllPackages.Diagnostics()
+		printDiagnostics(diagnostics)
+		if len(diagnostics) == 0 {
+			color.HiGreen("All the packages have been loaded without errors")
+		}
+	},
+}
+
+func printDiagnostics(diagnostics []godeep.PackageDiagnostic) {
+	printers := map[godeep.DiagnosticKind]func(format string, a ...interface{}){
+		godeep.DiagnosticUnknown: color.Red,
+		godeep.DiagnosticLoad:    color.Red,
+		godeep.DiagnosticParse:   color.HiYellow,
+		godeep.DiagnosticType:    color.HiMagenta,
+	}
+	packages := 0
+	for idx := 0; idx < len(diagnostics); {
+		pkgPath := diagnostics[idx].Package
+		end := idx
+		for end < len(diagnostics) && diagnostics[end].Package == pkgPath {
+			end++
+		}
+		packages++
+		color.HiBlue("%s: (%d)", pkgPath, end-idx)
+		for n := 1; idx < end; idx, n = idx+1, n+1 {
+			d := diagnostics[idx]
+			printers[d.Kind]("\t %d. %s error: %s", n, d.Kind, d)
+		}
+	}
+	if len(diagnostics) > 0 {
+		color.Red("Diagnostics: (%d) in (%d) packages", len(diagnostics), packages)
+	}
+}
+
+var CmdAffected = &cobra.Command{
+	Use:   "affected [files...]",
+	Short: "prints the packages whose tests must run for the changed files, as go test arguments",
+	Run: func(cmd *cobra.Command, args []string) {
+		rev, err := cmd.Flags().GetString(FlagRevision)
+		PrintOnErr(err)
+
+		var files []string
+		for _, f := range args {
+			absPath, err := filepath.Abs(f)
+			PanicOnErr(err)
+			files = append(files, absPath)
+		}
+		if rev != "" {
+			changed, err := gitChangedFiles(rev)
+			PanicOnErr(err)
+			files = append(files, changed...)
+		}
+		if len(files) == 0 {
+			return
+		}
+
+		// The output must be usable as the arguments of go test, so the progress is not printed
+		if AllPackages.Len() == 0 {
+			cwd, _ := os.Getwd()
+			err = godeep.FindPackages(AllPackages, cwd, nil)
+			PanicOnErr(err)
+		}
+		// Nothing is printed if no package is affected, not even an empty line
+		if affected := AllPackages.Affected(files); len(affected) > 0 {
+			fmt.Println(strings.Join(affected, " "))
+		}
+	},
+}
+
+// gitChangedFiles returns the absolute path of the files changed in the revision range of the
+// git repository of the current directory.
+func gitChangedFiles(rev string) ([]string, error) {
+	root, err := runGit("rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+	// The names are separated by NUL and not quoted, so they could have spaces or any other character
+	out, err := gitOutput("diff", "-z", "--name-only", rev)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, f := range strings.Split(out, "\x00") {
+		if f != "" {
+			files = append(files, filepath.Join(root, f))
+		}
+	}
+	return files, nil
+}
+
+func runGit(args ...string) (string, error) {
+	out, err := gitOutput(args...)
+	return strings.TrimSpace(out), err
+}
+
+// gitOutput returns the output of the git command as it is.
+func gitOutput(args ...string) (string, error) {
+	out, err := exec.Command("git", args...).Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return "", fmt.Errorf("git %s: %s", strings.Join(args, " "), strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", err
+	}
+	return string(out), nil
+}
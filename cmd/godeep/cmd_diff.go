@@ -0,0 +1,219 @@
+package main
+
+import (
+	"fmt"
+	"github.com/fatih/color"
+	"github.com/ronaksoft/godeep"
+	"github.com/spf13/cobra"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func init() {
+	RootCmd.AddCommand(CmdDiff, CmdAPIDiff)
+	CmdDiff.Flags().String(FlagFormat, "text", "output format: text, markdown")
+}
+
+func LoadSnapshot(filename string) (*godeep.Packages, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	pkgs := godeep.InitPackages()
+	err = pkgs.Unmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return pkgs, nil
+}
+
+// AnalyzeRevision analyzes the current directory as it was in the git revision, using a
+// temporary worktree.
+func AnalyzeRevision(rev string) (*godeep.Packages, error) {
+	root, err := runGit("rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+	cwd, _ := os.Getwd()
+	relPath, err := filepath.Rel(root, cwd)
+	if err != nil {
+		return nil, err
+	}
+	tempDir, err := ioutil.TempDir("", "godeep")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tempDir)
+	worktree := filepath.Join(tempDir, "worktree")
+	_, err = runGit("worktree", "add", "--detach", worktree, rev)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_, _ = runGit("worktree", "remove", "--force", worktree)
+	}()
+	pkgs := godeep.InitPackages()
+	err = godeep.FindPackages(pkgs, filepath.Join(worktree, relPath), nil)
+	if err != nil {
+		return nil, err
+	}
+	return pkgs, nil
+}
+
+// LoadSnapshotOrRevision loads the snapshot if the file exists, otherwise it analyzes the git
+// revision.
+func LoadSnapshotOrRevision(snapshotOrRev string) (*godeep.Packages, error) {
+	if _, err := os.Stat(snapshotOrRev); err == nil {
+		return LoadSnapshot(snapshotOrRev)
+	}
+	return AnalyzeRevision(snapshotOrRev)
+}
+
+var CmdDiff = &cobra.Command{
+	Use:   "diff <old.json> <new.json>",
+	Short: "prints the dependency and API changes between two exported snapshots",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		format, err := cmd.Flags().GetString(FlagFormat)
+		PrintOnErr(err)
+
+		oldPackages, err := LoadSnapshot(args[0])
+		PanicOnErr(err)
+		newPackages, err := LoadSnapshot(args[1])
+		PanicOnErr(err)
+		d := godeep.Compare(oldPackages, newPackages)
+		switch format {
+		case "markdown":
+			fmt.Print(d.Markdown())
+		case "text":
+			printDiff(d)
+		default:
+			PanicOnErr(fmt.Errorf("unsupported format: %s", format))
+		}
+	},
+}
+
+func printDiff(d *godeep.Diff) {
+	if d.Empty() {
+		color.HiGreen("No dependency changes")
+		return
+	}
+	printList := func(printer func(format string, a ...interface{}), title string, items []string) {
+		if len(items) == 0 {
+			return
+		}
+		printer("%s: (%d)", title, len(items))
+		for idx, i := range items {
+			printer("\t %d. %s", idx+1, i)
+		}
+	}
+	edges := func(edges []godeep.Edge) []string {
+		var res []string
+		for _, e := range edges {
+			res = append(res, fmt.Sprintf("%s -> %s", e.From, e.To))
+		}
+		return res
+	}
+	var cycles []string
+	for _, c := range d.AddedCycles {
+		cycles = append(cycles, fmt.Sprintf("(%s) %s", c.Kind, strings.Join(c.Path, " -> ")))
+	}
+	printList(color.Red, "New Cycles", cycles)
+	printList(color.HiYellow, "New Third-Party Packages", d.AddedThirdParty)
+	printList(color.HiGreen, "Added Packages", d.AddedPackages)
+	printList(color.HiRed, "Removed Packages", d.RemovedPackages)
+	printList(color.HiGreen, "Added Imports", edges(d.AddedEdges))
+	printList(color.HiRed, "Removed Imports", edges(d.RemovedEdges))
+	for _, e := range d.Exports {
+		color.HiMagenta("API Changes: %s", e.Package)
+		for _, i := range e.RemovedFunctions {
+			color.Red("\t - func %s", i)
+		}
+		for _, i := range e.AddedFunctions {
+			color.Green("\t + func %s", i)
+		}
+		for _, i := range e.RemovedTypes {
+			color.Red("\t - type %s", i)
+		}
+		for _, i := range e.AddedTypes {
+			color.Green("\t + type %s", i)
+		}
+		for _, i := range e.RemovedMethods {
+			color.Red("\t - %s", i)
+		}
+		for _, i := range e.AddedMethods {
+			color.Green("\t + %s", i)
+		}
+		for _, i := range e.RemovedFields {
+			color.Red("\t - field %s", i)
+		}
+		for _, i := range e.AddedFields {
+			color.Green("\t + field %s", i)
+		}
+		for _, i := range e.RemovedConstants {
+			color.Red("\t - const %s", i)
+		}
+		for _, i := range e.AddedConstants {
+			color.Green("\t + const %s", i)
+		}
+		for _, i := range e.RemovedVariables {
+			color.Red("\t - var %s", i)
+		}
+		for _, i := range e.AddedVariables {
+			color.Green("\t + var %s", i)
+		}
+	}
+}
+
+var CmdAPIDiff = &cobra.Command{
+	Use:   "apidiff <old> <new>",
+	Short: "classifies the exported API changes between two snapshots or git revisions and recommends a version bump",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		oldPackages, err := LoadSnapshotOrRevision(args[0])
+		PanicOnErr(err)
+		newPackages, err := LoadSnapshotOrRevision(args[1])
+		PanicOnErr(err)
+		d := godeep.CompareAPI(oldPackages, newPackages)
+		for _, m := range d.Modules {
+			module := m.Module
+			if module == "" {
+				module = "(unknown module)"
+			}
+			printer := color.HiGreen
+			switch m.Bump {
+			case godeep.BumpMajor:
+				printer = color.HiRed
+			case godeep.BumpMinor:
+				printer = color.HiYellow
+			}
+			printer("========== %s: %s (breaking: %d, compatible: %d) ========",
+				module, strings.ToUpper(m.Bump.String()), m.Breaking, m.Compatible,
+			)
+			pkgPath := ""
+			for _, c := range d.Changes {
+				if c.Module != m.Module {
+					continue
+				}
+				if c.Package != pkgPath {
+					pkgPath = c.Package
+					color.HiBlue("%s:", pkgPath)
+				}
+				printer := color.Green
+				verdict := "compatible"
+				if c.Breaking {
+					printer = color.Red
+					verdict = "breaking"
+				}
+				switch c.Change {
+				case godeep.ChangeModified:
+					printer("\t %s %s %s %s: %s => %s", verdict, c.Change, c.Kind, c.Name, c.Old, c.New)
+				default:
+					printer("\t %s %s %s %s", verdict, c.Change, c.Kind, c.Name)
+				}
+			}
+		}
+	},
+}
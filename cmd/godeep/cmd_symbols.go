@@ -0,0 +1,192 @@
+package main
+
+import (
+	"fmt"
+	"github.com/fatih/color"
+	"github.com/ronaksoft/godeep"
+	"github.com/spf13/cobra"
+	"os"
+	"sort"
+	"strings"
+)
+
+func init() {
+	RootCmd.AddCommand(CmdGenerics, CmdImplements, CmdImplementedBy, CmdUsage, CmdCallSites, CmdDeadAPI)
+	CmdDeadAPI.Flags().String(FlagConfig, ".godeep.yaml", "the rules file, its deadapi.allow patterns are not reported")
+	CmdDeadAPI.Flags().StringSlice(FlagAllow, nil, "patterns of the packages or the symbols which must not be reported")
+}
+
+var CmdGenerics = &cobra.Command{
+	Use:   "generics [pkg]",
+	Short: "prints the exported generic functions and types with the packages which instantiate them",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		EnsureAnalyzed()
+		pkgPath := ""
+		if len(args) > 0 {
+			pkgPath = args[0]
+		}
+		generics := AllPackages.Generics(pkgPath)
+		color.HiMagenta("Generics: (%d)", len(generics))
+		for idx, g := range generics {
+			kind := "type"
+			if g.Func {
+				kind = "func"
+			}
+			color.HiMagenta("\t %d. %s %s.%s", idx+1, kind, g.Package, g)
+			if len(g.Sites) == 0 {
+				fmt.Println("\t    not instantiated")
+			}
+			for _, s := range g.Sites {
+				fmt.Println(fmt.Sprintf("\t    %s [%s] x%d", s.Package, strings.Join(s.TypeArgs, ", "), s.Count))
+			}
+		}
+	},
+}
+
+var CmdImplements = &cobra.Command{
+	Use:   "implements <iface>",
+	Short: "prints the types which satisfy the interface, i.e. 'io.Reader' or 'example.com/a/b.Store'",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		EnsureTypes()
+		impls, err := AllPackages.Implements(args[0])
+		if err != nil {
+			PrintOnErr(err)
+			return
+		}
+		color.HiGreen("Implemented By: (%d)", len(impls))
+		for idx, impl := range impls {
+			color.HiGreen("\t %d. %s", idx+1, implementationType(impl))
+		}
+	},
+}
+
+var CmdImplementedBy = &cobra.Command{
+	Use:   "implementedBy <type>",
+	Short: "prints the interfaces which are satisfied by the type, i.e. 'example.com/a/b.Client'",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		EnsureTypes()
+		impls, err := AllPackages.ImplementedBy(args[0])
+		if err != nil {
+			PrintOnErr(err)
+			return
+		}
+		color.HiBlue("Implements: (%d)", len(impls))
+		for idx, impl := range impls {
+			if impl.Pointer {
+				color.HiBlue("\t %d. %s (by %s)", idx+1, impl.Interface, implementationType(impl))
+			} else {
+				color.HiBlue("\t %d. %s", idx+1, impl.Interface)
+			}
+		}
+	},
+}
+
+func implementationType(impl godeep.Implementation) string {
+	if impl.Pointer {
+		return "*" + impl.Type
+	}
+	return impl.Type
+}
+
+var CmdUsage = &cobra.Command{
+	Use:   "usage <from> <to>",
+	Short: "prints the symbols of the package 'to' which are referenced by the package 'from'",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		EnsureAnalyzed()
+		pkg := AllPackages.GetByPath(args[0])
+		if pkg == nil {
+			fmt.Println("Package not found:", args[0])
+			return
+		}
+		usage := pkg.Usage(args[1])
+		color.HiCyan("Referenced Symbols: (%d), References: (%d)", len(usage), pkg.Weight(args[1]))
+		for idx, u := range usage {
+			color.HiCyan("\t %d. %s x%d", idx+1, u.Name, u.Count)
+		}
+	},
+}
+
+var CmdCallSites = &cobra.Command{
+	Use:   "callsites <module-or-package>",
+	Short: "prints every reference to the symbols of the dependency, grouped by package and symbol",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		EnsureAnalyzed()
+		sites := AllPackages.CallSites(args[0])
+		totals := map[string]int{}
+		for idx := 0; idx < len(sites); {
+			from := sites[idx].From
+			end := idx
+			for end < len(sites) && sites[end].From == from {
+				end++
+			}
+			color.HiBlue("%s: (%d)", from, end-idx)
+			for idx < end {
+				symbol := fmt.Sprintf("%s.%s", sites[idx].Package, sites[idx].Symbol)
+				color.HiCyan("\t %s", symbol)
+				for ; idx < end && fmt.Sprintf("%s.%s", sites[idx].Package, sites[idx].Symbol) == symbol; idx++ {
+					totals[symbol]++
+					fmt.Println(fmt.Sprintf("\t    %s:%d %s",
+						sites[idx].Position.Filename, sites[idx].Position.Line, sites[idx].Kind,
+					))
+				}
+			}
+		}
+
+		symbols := make([]string, 0, len(totals))
+		for symbol := range totals {
+			symbols = append(symbols, symbol)
+		}
+		sort.Slice(symbols, func(i, j int) bool {
+			if totals[symbols[i]] != totals[symbols[j]] {
+				return totals[symbols[i]] > totals[symbols[j]]
+			}
+			return symbols[i] < symbols[j]
+		})
+		color.HiMagenta("Total: %d references to %d symbols", len(sites), len(symbols))
+		for idx, symbol := range symbols {
+			color.HiMagenta("\t %d. %s x%d", idx+1, symbol, totals[symbol])
+		}
+	},
+}
+
+var CmdDeadAPI = &cobra.Command{
+	Use:   "deadapi",
+	Short: "prints the exported symbols which are not referenced by the other packages",
+	Run: func(cmd *cobra.Command, args []string) {
+		config, err := cmd.Flags().GetString(FlagConfig)
+		PrintOnErr(err)
+		allow, err := cmd.Flags().GetStringSlice(FlagAllow)
+		PrintOnErr(err)
+
+		// The rules file is optional for this command
+		if _, err := os.Stat(config); err == nil {
+			rules, err := godeep.LoadRules(config)
+			PanicOnErr(err)
+			allow = append(allow, rules.DeadAPI.Allow...)
+		}
+		EnsureAnalyzed()
+		dead := AllPackages.DeadAPI(allow)
+		printers := map[godeep.DeadKind]func(format string, a ...interface{}){
+			godeep.DeadUnreferenced: color.Red,
+			godeep.DeadInternal:     color.HiYellow,
+		}
+		for _, kind := range []godeep.DeadKind{godeep.DeadUnreferenced, godeep.DeadInternal} {
+			var filtered []godeep.DeadSymbol
+			for _, d := range dead {
+				if d.Dead == kind {
+					filtered = append(filtered, d)
+				}
+			}
+			printer := printers[kind]
+			printer("Exported Symbols (%s): (%d)", kind, len(filtered))
+			for idx, d := range filtered {
+				printer("\t %d. %s %s.%s", idx+1, d.Kind, d.Package, d.Name)
+			}
+		}
+	},
+}
@@ -11,7 +11,23 @@ package main
 
 // Flag Names
 const (
-	FlagInteractive = "interactive"
-	FlagOutputDir   = "output_dir"
-	FlagInputDir    = "input_dir"
+	FlagInteractive  = "interactive"
+	FlagOutputDir    = "output_dir"
+	FlagInputDir     = "input_dir"
+	FlagAlternatives = "alternatives"
+	FlagDirect       = "direct"
+	FlagTop          = "top"
+	FlagConfig       = "config"
+	FlagRevision     = "rev"
+	FlagFormat       = "format"
+	FlagCluster      = "cluster"
+	FlagPrefixDepth  = "prefix_depth"
+	FlagStd          = "std"
+	FlagFocus        = "focus"
+	FlagDepth        = "depth"
+	FlagAllow        = "allow"
+	FlagPatterns     = "patterns"
+	FlagModules      = "modules"
+	FlagNoCache      = "no_cache"
+	FlagStrict       = "strict"
 )
@@ -11,13 +11,18 @@ import (
 
 var (
 	AllPackages = godeep.InitPackages()
+	Interactive = false
 )
 
 func main() {
 	preRootCmd := &cobra.Command{
 		Use: "GoDeep",
+		// Flags are parsed by the RootCmd, otherwise the flags of the sub commands are
+		// rejected as unknown flags
+		DisableFlagParsing: true,
 		Run: func(cmd *cobra.Command, args []string) {
-			if v, _ := cmd.Flags().GetBool(FlagInteractive); v {
+			if len(args) > 0 && args[0] == fmt.Sprintf("--%s", FlagInteractive) {
+				Interactive = true
 				p := prompt.New(executor, completer)
 				p.Run()
 			} else {
@@ -26,7 +31,6 @@ func main() {
 			}
 		},
 	}
-	preRootCmd.Flags().Bool(FlagInteractive, false, "enable interactive mode")
 	_ = preRootCmd.Execute()
 }
 
@@ -93,5 +97,7 @@ func init() {
 	fs := RootCmd.PersistentFlags()
 	fs.String(FlagOutputDir, "./", "generated file will be stored here")
 	fs.String(FlagInputDir, "./", "default place to look for files")
-
+	fs.StringSlice(FlagPatterns, nil, "package patterns loaded in every module, default is './...'")
+	fs.Bool(FlagNoCache, false, "load all the packages instead of restoring the unchanged ones from the cache")
+	fs.Bool(FlagStrict, false, "fail the analysis if any package has load, parse or type errors")
 }
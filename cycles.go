@@ -0,0 +1,139 @@
+package godeep
+
+import (
+	"path"
+)
+
+type CycleKind int
+
+const (
+	// CycleImport is a cycle between packages through their regular imports.
+	CycleImport CycleKind = iota
+	// CycleTest is a cycle which only exists if the imports of test files (including the
+	// external _test packages) are counted as imports of the package under test.
+	CycleTest
+	// CycleGroup is a cycle between directories, where every package is replaced by its
+	// parent directory.
+	CycleGroup
+	// CycleModule is a cycle between the analyzed modules, which is legal but they could not be
+	// released independently.
+	CycleModule
+)
+
+func (k CycleKind) String() string {
+	switch k {
+	case CycleImport:
+		return "import"
+	case CycleTest:
+		return "test"
+	case CycleGroup:
+		return "directory"
+	case CycleModule:
+		return "module"
+	}
+	return "unknown"
+}
+
+type Cycle struct {
+	Kind CycleKind
+	// Members are all the nodes in the strongly connected component, sorted.
+	Members []string
+	// Path is the shortest cycle inside the component, the first and the last
+	// elements are the same.
+	Path []string
+}
+
+// Cycles returns all the import cycles, the cycles introduced by test files, the cycles
+// between directory groups and the cycles between the analyzed modules.
+func (a *Packages) Cycles() []Cycle {
+	a.mtx.RLock()
+	defer a.mtx.RUnlock()
+
+	var cycles []Cycle
+	importGraph := a.importGraph(false)
+	seen := map[string]bool{}
+	for _, c := range findCycles(importGraph, CycleImport) {
+		seen[cycleKey(c.Members)] = true
+		cycles = append(cycles, c)
+	}
+	for _, c := range findCycles(a.importGraph(true), CycleTest) {
+		if !seen[cycleKey(c.Members)] {
+			cycles = append(cycles, c)
+		}
+	}
+	groupGraph := graph{}
+	for from, tos := range importGraph {
+		for _, to := range tos {
+			if path.Dir(from) != path.Dir(to) {
+				groupGraph.addEdge(path.Dir(from), path.Dir(to))
+			}
+		}
+	}
+	cycles = append(cycles, findCycles(groupGraph, CycleGroup)...)
+	cycles = append(cycles, findCycles(a.mainModuleGraph(), CycleModule)...)
+	return cycles
+}
+
+// importGraph builds the graph of the imported edges. If withTests is set, the external test
+// packages are merged into the packages they test and test-only imports are included.
+func (a *Packages) importGraph(withTests bool) graph {
+	g := graph{}
+	for pkgPath, p := range a.byPath {
+		from := pkgPath
+		if p.forTest != "" {
+			if !withTests {
+				continue
+			}
+			from = p.forTest
+		}
+		if _, ok := g[from]; !ok {
+			g[from] = nil
+		}
+		imported := p.imported
+		if withTests {
+			imported = append(imported[:len(imported):len(imported)], p.testImported...)
+		}
+		for _, to := range imported {
+			if to != from {
+				g.addEdge(from, to)
+			}
+		}
+	}
+	return g
+}
+
+func findCycles(g graph, kind CycleKind) []Cycle {
+	var cycles []Cycle
+	for _, members := range g.scc() {
+		if len(members) < 2 {
+			continue
+		}
+		allowed := make(map[string]bool, len(members))
+		for _, m := range members {
+			allowed[m] = true
+		}
+		var witness []string
+		for _, m := range members {
+			p := g.shortestPath(m, m, func(from, to string) bool {
+				return !allowed[to]
+			})
+			if p != nil && (witness == nil || len(p) < len(witness)) {
+				witness = p
+			}
+		}
+		cycles = append(cycles, Cycle{
+			Kind:    kind,
+			Members: members,
+			Path:    witness,
+		})
+	}
+	return cycles
+}
+
+func cycleKey(members []string) string {
+	key := ""
+	for _, m := range members {
+		key += m + "\n"
+	}
+	return key
+}
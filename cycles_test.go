@@ -0,0 +1,117 @@
+package godeep
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCyclesThroughExternalTestPackage(t *testing.T) {
+	dir := writeModule(t, map[string]string{
+		"go.mod": "module example.com/c\n\ngo 1.22\n",
+		"a/a.go": `package a
+
+import "example.com/c/b"
+
+var A = b.B
+`,
+		"b/b.go": `package b
+
+var B = 1
+`,
+		// The external test package of b imports a, which imports b
+		"b/b_test.go": `package b_test
+
+import (
+	"testing"
+
+	"example.com/c/a"
+)
+
+func TestB(t *testing.T) {
+	_ = a.A
+}
+`,
+		"g1/x/x.go": `package x
+
+import "example.com/c/g2/y"
+
+var X = y.Y
+`,
+		"g1/w/w.go": `package w
+
+var W = 1
+`,
+		"g2/y/y.go": `package y
+
+var Y = 1
+`,
+		"g2/z/z.go": `package z
+
+import "example.com/c/g1/w"
+
+var Z = w.W
+`,
+	})
+	cycles := analyze(t, dir).Cycles()
+
+	expected := []Cycle{
+		{
+			Kind:    CycleTest,
+			Members: []string{"example.com/c/a", "example.com/c/b"},
+			Path:    []string{"example.com/c/a", "example.com/c/b", "example.com/c/a"},
+		},
+		{
+			Kind:    CycleGroup,
+			Members: []string{"example.com/c/g1", "example.com/c/g2"},
+			Path:    []string{"example.com/c/g1", "example.com/c/g2", "example.com/c/g1"},
+		},
+	}
+	if len(cycles) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, cycles)
+	}
+	for idx := range expected {
+		c := cycles[idx]
+		if c.Kind != expected[idx].Kind || !reflect.DeepEqual(c.Members, expected[idx].Members) ||
+			len(c.Path) != len(expected[idx].Path) || c.Path[0] != c.Path[len(c.Path)-1] {
+			t.Errorf("expected %v, got %v", expected[idx], c)
+		}
+	}
+}
+
+func TestCyclesOfSnapshot(t *testing.T) {
+	a := InitPackages()
+	err := a.Unmarshal([]byte(`{"version": 1, "packages": [
+		{"name": "a", "path": "example.com/m1/a", "module": "example.com/m1", "imported": ["example.com/m1/b"]},
+		{"name": "b", "path": "example.com/m1/b", "module": "example.com/m1", "imported": ["example.com/m1/c"]},
+		{"name": "c", "path": "example.com/m1/c", "module": "example.com/m1", "imported": ["example.com/m1/a", "example.com/m2/d"]},
+		{"name": "d", "path": "example.com/m2/d", "module": "example.com/m2", "imported": ["example.com/m1/a"]}
+	], "modules": [
+		{"path": "example.com/m1", "main": true, "imports": {"example.com/m2": 1}},
+		{"path": "example.com/m2", "main": true, "imports": {"example.com/m1": 1}}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	kinds := map[CycleKind][]Cycle{}
+	for _, c := range a.Cycles() {
+		kinds[c.Kind] = append(kinds[c.Kind], c)
+	}
+
+	imports := kinds[CycleImport]
+	if len(imports) != 1 || len(imports[0].Members) != 4 {
+		t.Fatalf("expected a single import cycle of 4 packages, got %v", imports)
+	}
+	// The witness is the shortest cycle of the component, a -> b -> c -> a
+	witness := []string{"example.com/m1/a", "example.com/m1/b", "example.com/m1/c", "example.com/m1/a"}
+	if len(imports[0].Path) != len(witness) {
+		t.Errorf("expected a witness like %v, got %v", witness, imports[0].Path)
+	}
+	// The import cycle is not reported again as a test cycle
+	if len(kinds[CycleTest]) != 0 {
+		t.Errorf("expected no test cycles, got %v", kinds[CycleTest])
+	}
+	modules := kinds[CycleModule]
+	if len(modules) != 1 || !reflect.DeepEqual(modules[0].Members, []string{"example.com/m1", "example.com/m2"}) {
+		t.Errorf("expected the cycle of example.com/m1 and example.com/m2, got %v", modules)
+	}
+}
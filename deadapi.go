@@ -0,0 +1,97 @@
+package godeep
+
+import (
+	"sort"
+	"strings"
+)
+
+type DeadKind int
+
+const (
+	// DeadUnreferenced symbols are not referenced by any analyzed package, even their own package
+	DeadUnreferenced DeadKind = iota
+	// DeadInternal symbols are only referenced by their own package, they could be unexported
+	DeadInternal
+)
+
+func (k DeadKind) String() string {
+	switch k {
+	case DeadUnreferenced:
+		return "unreferenced"
+	case DeadInternal:
+		return "only internal"
+	}
+	return "unknown"
+}
+
+// DeadSymbol is an exported function, type, variable or constant which is not referenced by
+// the other analyzed packages.
+type DeadSymbol struct {
+	Package string
+	Name    string
+	Kind    APIKind
+	Dead    DeadKind
+}
+
+// DeadAPIRules is the deadapi section of the rules file. Allow are the patterns of the packages,
+// i.e. the public library modules, or the symbols, i.e. 'example.com/a/b.Client', which are
+// exported for the code outside the analyzed packages and must not be reported.
+type DeadAPIRules struct {
+	Allow []string `yaml:"allow"`
+}
+
+// DeadAPI returns the exported symbols which are not referenced by the other analyzed packages,
+// sorted by package and name. The references of the test packages and the internal test files
+// are counted, the methods and the fields are not reported but they count as the references to
+// their type. Main and test packages are ignored.
+func (a *Packages) DeadAPI(allow []string) []DeadSymbol {
+	a.mtx.RLock()
+	defer a.mtx.RUnlock()
+	allowed := compilePatterns(allow)
+
+	internal, external := map[string]bool{}, map[string]bool{}
+	for pkgPath, p := range a.byPath {
+		for to, symbols := range p.usage {
+			for _, s := range symbols {
+				// Methods and fields are referenced by their type, i.e. 'Client.Do'
+				key := to + "." + strings.SplitN(s.Name, ".", 2)[0]
+				if to == pkgPath {
+					internal[key] = true
+				} else {
+					external[key] = true
+				}
+			}
+		}
+	}
+
+	var res []DeadSymbol
+	for _, pkgPath := range keys(a.byPath) {
+		p := a.byPath[pkgPath]
+		if p.forTest != "" || p.name == "main" || matchAny(allowed, pkgPath) {
+			continue
+		}
+		for _, s := range p.API() {
+			switch s.Kind {
+			case APIFunc, APIType, APIVariable, APIConstant:
+			default:
+				continue
+			}
+			key := pkgPath + "." + s.Name
+			if external[key] || matchAny(allowed, key) {
+				continue
+			}
+			d := DeadSymbol{Package: pkgPath, Name: s.Name, Kind: s.Kind, Dead: DeadUnreferenced}
+			if internal[key] {
+				d.Dead = DeadInternal
+			}
+			res = append(res, d)
+		}
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].Package != res[j].Package {
+			return res[i].Package < res[j].Package
+		}
+		return res[i].Name < res[j].Name
+	})
+	return res
+}
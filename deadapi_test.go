@@ -0,0 +1,57 @@
+package godeep
+
+import (
+	"testing"
+)
+
+func TestDeadAPICountsInternalTestReferences(t *testing.T) {
+	dir := writeModule(t, map[string]string{
+		"go.mod": "module example.com/s\n\ngo 1.22\n",
+		"a/a.go": `package a
+
+type Mode int
+
+const (
+	ModeA Mode = iota
+	ModeB
+)
+
+func Use() {}
+
+func Unused() {}
+`,
+		"c/c.go": `package c
+
+import "example.com/s/a"
+
+var Mode = a.ModeA
+`,
+		"c/c_internal_test.go": `package c
+
+import (
+	"testing"
+
+	"example.com/s/a"
+)
+
+func TestUse(t *testing.T) {
+	a.Use()
+	_ = a.ModeB
+}
+`,
+	})
+	a := analyze(t, dir)
+
+	dead := map[string]DeadKind{}
+	for _, d := range a.DeadAPI(nil) {
+		dead[d.Package+"."+d.Name] = d.Dead
+	}
+	for _, name := range []string{"example.com/s/a.Use", "example.com/s/a.ModeB", "example.com/s/a.ModeA"} {
+		if _, ok := dead[name]; ok {
+			t.Errorf("%s is referenced by c, but it is reported as dead", name)
+		}
+	}
+	if kind, ok := dead["example.com/s/a.Unused"]; !ok || kind != DeadUnreferenced {
+		t.Errorf("expected example.com/s/a.Unused to be unreferenced, got %v", dead)
+	}
+}
@@ -0,0 +1,113 @@
+package godeep
+
+import (
+	"golang.org/x/tools/go/packages"
+)
+
+type DiagnosticKind int
+
+const (
+	DiagnosticUnknown DiagnosticKind = iota
+	// DiagnosticLoad errors are reported by the go command, i.e. the missing imports
+	DiagnosticLoad
+	DiagnosticParse
+	DiagnosticType
+)
+
+func (k DiagnosticKind) String() string {
+	switch k {
+	case DiagnosticLoad:
+		return "load"
+	case DiagnosticParse:
+		return "parse"
+	case DiagnosticType:
+		return "type"
+	}
+	return "unknown"
+}
+
+func (k DiagnosticKind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+func (k *DiagnosticKind) UnmarshalText(text []byte) error {
+	for *k = DiagnosticLoad; *k <= DiagnosticType; *k++ {
+		if k.String() == string(text) {
+			return nil
+		}
+	}
+	*k = DiagnosticUnknown
+	return nil
+}
+
+// Diagnostic is an error of loading a package. The packages with errors are kept with whatever
+// could be loaded, Position is 'file:line:col' or empty if the error has no position.
+type Diagnostic struct {
+	Kind     DiagnosticKind `json:"kind"`
+	Position string         `json:"position,omitempty"`
+	Message  string         `json:"message"`
+}
+
+func (d Diagnostic) String() string {
+	if d.Position == "" || d.Position == "-" {
+		return d.Message
+	}
+	return d.Position + ": " + d.Message
+}
+
+// PackageDiagnostic is a diagnostic of an analyzed package.
+type PackageDiagnostic struct {
+	Package string
+	Diagnostic
+}
+
+// Diagnostics returns the errors of all the analyzed packages, sorted by package. The errors of
+// every package are in the order they are reported.
+func (a *Packages) Diagnostics() []PackageDiagnostic {
+	a.mtx.RLock()
+	defer a.mtx.RUnlock()
+	var res []PackageDiagnostic
+	for _, pkgPath := range keys(a.byPath) {
+		for _, d := range a.byPath[pkgPath].errors {
+			res = append(res, PackageDiagnostic{Package: pkgPath, Diagnostic: d})
+		}
+	}
+	return res
+}
+
+// appendDiagnostics appends the errors which are not already reported, since the test variants
+// of a package report the errors of the package too.
+func appendDiagnostics(diagnostics []Diagnostic, errs []packages.Error) []Diagnostic {
+	for _, err := range errs {
+		d := Diagnostic{
+			Position: err.Pos,
+			Message:  err.Msg,
+		}
+		switch err.Kind {
+		case packages.ListError:
+			d.Kind = DiagnosticLoad
+		case packages.ParseError:
+			d.Kind = DiagnosticParse
+		case packages.TypeError:
+			d.Kind = DiagnosticType
+		}
+		if !containsDiagnostic(diagnostics, d) {
+			diagnostics = append(diagnostics, d)
+		}
+	}
+	return diagnostics
+}
+
+func containsDiagnostic(diagnostics []Diagnostic, d Diagnostic) bool {
+	for _, x := range diagnostics {
+		if x == d {
+			return true
+		}
+	}
+	return false
+}
+
+// resolved returns false for the imported packages which could not be found.
+func resolved(pkg *packages.Package) bool {
+	return len(pkg.Errors) == 0 || pkg.Name != "" || len(pkg.GoFiles) > 0
+}
@@ -0,0 +1,299 @@
+package godeep
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type NodeKind int
+
+const (
+	NodeInternal NodeKind = iota
+	NodeThirdParty
+	NodeStd
+)
+
+func (k NodeKind) String() string {
+	switch k {
+	case NodeInternal:
+		return "internal"
+	case NodeThirdParty:
+		return "third-party"
+	case NodeStd:
+		return "std"
+	}
+	return "unknown"
+}
+
+type ClusterBy int
+
+const (
+	ClusterNone ClusterBy = iota
+	// ClusterModule groups the packages by their module in the module graph. The other packages
+	// are grouped by their first three path elements, which is the module path for most of them.
+	ClusterModule
+	// ClusterPrefix groups the packages by the first DiagramOptions.PrefixDepth elements of
+	// their path.
+	ClusterPrefix
+)
+
+type DiagramOptions struct {
+	Cluster     ClusterBy
+	PrefixDepth int
+	// Std if set, the standard library packages are included.
+	Std bool
+	// Focus if set, only the packages which are at most Depth imports away from the focused
+	// package, either imported by it or importing it, are included.
+	Focus string
+	Depth int
+	// Modules if set, the nodes are the modules and the edges are the edges of the module graph.
+	Modules bool
+}
+
+type diagramNode struct {
+	Path string
+	Kind NodeKind
+	// Broken is set for the packages which have load, parse or type errors
+	Broken bool
+}
+
+type diagramCluster struct {
+	Name  string
+	Nodes []diagramNode
+}
+
+type diagramEdge struct {
+	From  string
+	To    string
+	Cycle bool
+	// Weight is the number of the references to the symbols of the imported package, or the
+	// number of the package edges behind the edge of the module graph
+	Weight int
+}
+
+// diagram is the view of the import graph which is rendered by the diagram templates.
+type diagram struct {
+	Clusters []diagramCluster
+	Edges    []diagramEdge
+	ids      map[string]string
+}
+
+// id returns an identifier for the node which is safe to be used in all the diagram languages.
+func (d *diagram) id(pkgPath string) string {
+	return d.ids[pkgPath]
+}
+
+// DOT renders the import graph in the Graphviz DOT language.
+func (a *Packages) DOT(opt DiagramOptions) []byte {
+	return []byte(a.diagram(opt).DOT())
+}
+
+// Mermaid renders the import graph as a Mermaid flowchart.
+func (a *Packages) Mermaid(opt DiagramOptions) []byte {
+	return []byte(a.diagram(opt).Mermaid())
+}
+
+// PlantUML renders the import graph as a PlantUML component diagram.
+func (a *Packages) PlantUML(opt DiagramOptions) []byte {
+	return []byte(a.diagram(opt).PlantUML())
+}
+
+func (a *Packages) diagram(opt DiagramOptions) *diagram {
+	a.mtx.RLock()
+	defer a.mtx.RUnlock()
+
+	g := a.importGraph(false)
+	if opt.Modules {
+		g = a.mainModuleGraph()
+	}
+	inCycle := map[string]int{}
+	for idx, members := range g.scc() {
+		if len(members) < 2 {
+			continue
+		}
+		for _, m := range members {
+			inCycle[m] = idx + 1
+		}
+	}
+
+	d := &diagram{}
+	nodes := map[string]bool{}
+	if opt.Modules {
+		// Every module is its own cluster
+		if opt.Cluster == ClusterModule {
+			opt.Cluster = ClusterNone
+		}
+		for path := range a.modules {
+			nodes[path] = true
+		}
+		for _, e := range a.moduleEdges() {
+			d.Edges = append(d.Edges, diagramEdge{
+				From:   e.From,
+				To:     e.To,
+				Cycle:  inCycle[e.From] != 0 && inCycle[e.From] == inCycle[e.To],
+				Weight: e.Weight,
+			})
+		}
+	} else {
+		for pkgPath, p := range a.byPath {
+			if p.forTest != "" {
+				continue
+			}
+			nodes[pkgPath] = true
+			for _, to := range p.imported {
+				if !opt.Std && a.isStd(to) {
+					continue
+				}
+				nodes[to] = true
+				d.Edges = append(d.Edges, diagramEdge{
+					From:   pkgPath,
+					To:     to,
+					Cycle:  inCycle[pkgPath] != 0 && inCycle[pkgPath] == inCycle[to],
+					Weight: p.Weight(to),
+				})
+			}
+		}
+	}
+	if opt.Focus != "" {
+		nodes, d.Edges = focusDiagram(d.Edges, opt.Focus, opt.Depth)
+	}
+	sort.Slice(d.Edges, func(i, j int) bool {
+		if d.Edges[i].From != d.Edges[j].From {
+			return d.Edges[i].From < d.Edges[j].From
+		}
+		return d.Edges[i].To < d.Edges[j].To
+	})
+
+	clusters := map[string]*diagramCluster{}
+	for n := range nodes {
+		node := diagramNode{
+			Path: n,
+			Kind: a.nodeKind(n),
+		}
+		if p := a.byPath[n]; p != nil && len(p.errors) > 0 {
+			node.Broken = true
+		}
+		name := a.clusterName(node, opt)
+		if clusters[name] == nil {
+			clusters[name] = &diagramCluster{Name: name}
+		}
+		clusters[name].Nodes = append(clusters[name].Nodes, node)
+	}
+	for _, c := range clusters {
+		sort.Slice(c.Nodes, func(i, j int) bool {
+			return c.Nodes[i].Path < c.Nodes[j].Path
+		})
+		d.Clusters = append(d.Clusters, *c)
+	}
+	sort.Slice(d.Clusters, func(i, j int) bool {
+		return d.Clusters[i].Name < d.Clusters[j].Name
+	})
+	d.ids = make(map[string]string, len(nodes))
+	for _, c := range d.Clusters {
+		for _, n := range c.Nodes {
+			d.ids[n.Path] = fmt.Sprintf("n%d", len(d.ids))
+		}
+	}
+	return d
+}
+
+// focusDiagram keeps the nodes which are reachable from the focus in at most depth steps,
+// either by following the edges or by following them backward, and the edges between them.
+func focusDiagram(edges []diagramEdge, focus string, depth int) (map[string]bool, []diagramEdge) {
+	forward, backward := graph{}, graph{}
+	for _, e := range edges {
+		forward.addEdge(e.From, e.To)
+		backward.addEdge(e.To, e.From)
+	}
+	nodes := map[string]bool{focus: true}
+	for _, g := range []graph{forward, backward} {
+		distances := map[string]int{focus: 0}
+		queue := []string{focus}
+		for len(queue) > 0 {
+			n := queue[0]
+			queue = queue[1:]
+			if depth > 0 && distances[n] >= depth {
+				continue
+			}
+			for _, m := range g[n] {
+				if _, ok := distances[m]; ok {
+					continue
+				}
+				distances[m] = distances[n] + 1
+				nodes[m] = true
+				queue = append(queue, m)
+			}
+		}
+	}
+	var focused []diagramEdge
+	for _, e := range edges {
+		if nodes[e.From] && nodes[e.To] {
+			focused = append(focused, e)
+		}
+	}
+	return nodes, focused
+}
+
+func (a *Packages) nodeKind(pkgPath string) NodeKind {
+	switch {
+	case a.byPath[pkgPath] != nil, a.modules[pkgPath] != nil && a.modules[pkgPath].Main:
+		return NodeInternal
+	case a.isStd(pkgPath):
+		return NodeStd
+	default:
+		return NodeThirdParty
+	}
+}
+
+func (a *Packages) clusterName(n diagramNode, opt DiagramOptions) string {
+	switch opt.Cluster {
+	case ClusterModule:
+		if n.Kind == NodeStd {
+			return "std"
+		}
+		if p := a.byPath[n.Path]; p != nil && p.module != "" {
+			return p.module
+		}
+		// The module graph knows the modules of the dependencies
+		module := ""
+		for path := range a.modules {
+			if hasPathPrefix(n.Path, path) && len(path) > len(module) {
+				module = path
+			}
+		}
+		if module != "" {
+			return module
+		}
+		return pathPrefix(n.Path, 3)
+	case ClusterPrefix:
+		if n.Kind == NodeStd {
+			return "std"
+		}
+		return pathPrefix(n.Path, opt.PrefixDepth)
+	}
+	return ""
+}
+
+// isStd returns true if the package belongs to the standard library. The analyzed packages, their
+// modules and the recorded dependencies are not in it, the other paths are guessed by isStdPath.
+func (a *Packages) isStd(pkgPath string) bool {
+	if _, ok := a.dependencies[pkgPath]; ok || a.byPath[pkgPath] != nil || a.modules[pkgPath] != nil {
+		return false
+	}
+	return isStdPath(pkgPath)
+}
+
+// isStdPath returns true if the path looks like a package of the standard library, i.e. the first
+// element of the path does not contain a dot.
+func isStdPath(pkgPath string) bool {
+	return !strings.Contains(strings.SplitN(pkgPath, "/", 2)[0], ".")
+}
+
+func pathPrefix(pkgPath string, depth int) string {
+	parts := strings.Split(pkgPath, "/")
+	if depth > 0 && len(parts) > depth {
+		parts = parts[:depth]
+	}
+	return strings.Join(parts, "/")
+}
@@ -0,0 +1,173 @@
+package godeep
+
+import (
+	"sort"
+)
+
+type Edge struct {
+	From string
+	To   string
+}
+
+// Diff is the difference between two analyses of the same code base, usually two snapshots
+// of the base and the head of a pull request.
+type Diff struct {
+	AddedPackages   []string
+	RemovedPackages []string
+	AddedEdges      []Edge
+	RemovedEdges    []Edge
+	// AddedThirdParty are the third-party packages which were not imported by any package before
+	AddedThirdParty []string
+	AddedCycles     []Cycle
+	// Exports are the changes of the exported items of the packages which exist in both
+	Exports []ExportsDiff
+}
+
+type ExportsDiff struct {
+	Package          string
+	AddedFunctions   []string
+	RemovedFunctions []string
+	AddedTypes       []string
+	RemovedTypes     []string
+	AddedMethods     []string
+	RemovedMethods   []string
+	AddedFields      []string
+	RemovedFields    []string
+	AddedConstants   []string
+	RemovedConstants []string
+	AddedVariables   []string
+	RemovedVariables []string
+}
+
+func (d *Diff) Empty() bool {
+	return len(d.AddedPackages) == 0 && len(d.RemovedPackages) == 0 &&
+		len(d.AddedEdges) == 0 && len(d.RemovedEdges) == 0 &&
+		len(d.AddedCycles) == 0 && len(d.Exports) == 0
+}
+
+// Compare returns the changes from oldPackages to newPackages.
+func Compare(oldPackages, newPackages *Packages) *Diff {
+	oldPackages.mtx.RLock()
+	newPackages.mtx.RLock()
+	d := &Diff{}
+	d.AddedPackages, d.RemovedPackages = diffStrings(keys(oldPackages.byPath), keys(newPackages.byPath))
+	d.AddedEdges, d.RemovedEdges = diffEdges(oldPackages.edges(), newPackages.edges())
+	d.AddedThirdParty, _ = diffStrings(oldPackages.thirdParty(), newPackages.thirdParty())
+	for _, pkgPath := range keys(newPackages.byPath) {
+		o, n := oldPackages.byPath[pkgPath], newPackages.byPath[pkgPath]
+		if o == nil {
+			continue
+		}
+		e := ExportsDiff{Package: pkgPath}
+		e.AddedFunctions, e.RemovedFunctions = diffStrings(o.exportedFunctions, n.exportedFunctions)
+		e.AddedTypes, e.RemovedTypes = diffStrings(typeStrings(o.exportedTypes), typeStrings(n.exportedTypes))
+		e.AddedMethods, e.RemovedMethods = diffStrings(methodStrings(o.exportedTypes), methodStrings(n.exportedTypes))
+		e.AddedFields, e.RemovedFields = diffStrings(fieldStrings(o.exportedTypes), fieldStrings(n.exportedTypes))
+		e.AddedConstants, e.RemovedConstants = diffStrings(valueStrings(o.exportedConstants), valueStrings(n.exportedConstants))
+		e.AddedVariables, e.RemovedVariables = diffStrings(valueStrings(o.exportedVariables), valueStrings(n.exportedVariables))
+		if len(e.AddedFunctions)+len(e.RemovedFunctions)+len(e.AddedTypes)+len(e.RemovedTypes)+
+			len(e.AddedMethods)+len(e.RemovedMethods)+len(e.AddedFields)+len(e.RemovedFields)+
+			len(e.AddedConstants)+len(e.RemovedConstants)+len(e.AddedVariables)+len(e.RemovedVariables) > 0 {
+			d.Exports = append(d.Exports, e)
+		}
+	}
+	newPackages.mtx.RUnlock()
+	oldPackages.mtx.RUnlock()
+
+	oldCycles := map[string]bool{}
+	for _, c := range oldPackages.Cycles() {
+		oldCycles[c.Kind.String()+cycleKey(c.Members)] = true
+	}
+	for _, c := range newPackages.Cycles() {
+		if !oldCycles[c.Kind.String()+cycleKey(c.Members)] {
+			d.AddedCycles = append(d.AddedCycles, c)
+		}
+	}
+	return d
+}
+
+func (a *Packages) edges() []Edge {
+	var edges []Edge
+	for pkgPath, p := range a.byPath {
+		for _, to := range p.imported {
+			edges = append(edges, Edge{From: pkgPath, To: to})
+		}
+	}
+	return edges
+}
+
+// thirdParty returns the imported packages which are neither analyzed nor in the standard library.
+func (a *Packages) thirdParty() []string {
+	var res []string
+	for _, p := range a.byPath {
+		for _, to := range p.imported {
+			if a.byPath[to] == nil && !a.isStd(to) && !containsString(res, to) {
+				res = append(res, to)
+			}
+		}
+	}
+	return res
+}
+
+// diffStrings returns the sorted items which are only in b (added) and only in a (removed).
+func diffStrings(a, b []string) (added, removed []string) {
+	inA := make(map[string]bool, len(a))
+	for _, i := range a {
+		inA[i] = true
+	}
+	inB := make(map[string]bool, len(b))
+	for _, i := range b {
+		inB[i] = true
+		if !inA[i] {
+			added = append(added, i)
+		}
+	}
+	for _, i := range a {
+		if !inB[i] {
+			removed = append(removed, i)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
+
+func diffEdges(a, b []Edge) (added, removed []Edge) {
+	inA := make(map[Edge]bool, len(a))
+	for _, e := range a {
+		inA[e] = true
+	}
+	inB := make(map[Edge]bool, len(b))
+	for _, e := range b {
+		inB[e] = true
+		if !inA[e] {
+			added = append(added, e)
+		}
+	}
+	for _, e := range a {
+		if !inB[e] {
+			removed = append(removed, e)
+		}
+	}
+	sortEdges(added)
+	sortEdges(removed)
+	return added, removed
+}
+
+func sortEdges(edges []Edge) {
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].From != edges[j].From {
+			return edges[i].From < edges[j].From
+		}
+		return edges[i].To < edges[j].To
+	})
+}
+
+func keys(byPath map[string]*Package) []string {
+	res := make([]string, 0, len(byPath))
+	for k := range byPath {
+		res = append(res, k)
+	}
+	sort.Strings(res)
+	return res
+}
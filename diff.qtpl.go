@@ -0,0 +1,575 @@
+// Code generated by qtc from "diff.qtpl". DO NOT EDIT.
+// See https://github.com/valyala/quicktemplate for details.
+
+// Markdown rendering of the dependency changes, to be posted as a pull request comment
+
+//line diff.qtpl:3
+package godeep
+
+//line diff.qtpl:3
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line diff.qtpl:3
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+//line diff.qtpl:3
+func (d *Diff) StreamMarkdown(qw422016 *qt422016.Writer) {
+//line diff.qtpl:3
+	qw422016.N().S(`### Dependency changes`)
+//line diff.qtpl:4
+	qw422016.N().S(`
+`)
+//line diff.qtpl:5
+	qw422016.N().S(`
+`)
+//line diff.qtpl:6
+	if d.Empty() {
+//line diff.qtpl:6
+		qw422016.N().S(`No dependency changes.`)
+//line diff.qtpl:7
+		qw422016.N().S(`
+`)
+//line diff.qtpl:8
+		return
+//line diff.qtpl:9
+	}
+//line diff.qtpl:9
+	qw422016.N().S(`| Change | Count |`)
+//line diff.qtpl:10
+	qw422016.N().S(`
+`)
+//line diff.qtpl:10
+	qw422016.N().S(`|---|---|`)
+//line diff.qtpl:11
+	qw422016.N().S(`
+`)
+//line diff.qtpl:11
+	qw422016.N().S(`| Added packages |`)
+//line diff.qtpl:12
+	qw422016.N().D(len(d.AddedPackages))
+//line diff.qtpl:12
+	qw422016.N().S(`|`)
+//line diff.qtpl:12
+	qw422016.N().S(`
+`)
+//line diff.qtpl:12
+	qw422016.N().S(`| Removed packages |`)
+//line diff.qtpl:13
+	qw422016.N().D(len(d.RemovedPackages))
+//line diff.qtpl:13
+	qw422016.N().S(`|`)
+//line diff.qtpl:13
+	qw422016.N().S(`
+`)
+//line diff.qtpl:13
+	qw422016.N().S(`| Added imports |`)
+//line diff.qtpl:14
+	qw422016.N().D(len(d.AddedEdges))
+//line diff.qtpl:14
+	qw422016.N().S(`|`)
+//line diff.qtpl:14
+	qw422016.N().S(`
+`)
+//line diff.qtpl:14
+	qw422016.N().S(`| Removed imports |`)
+//line diff.qtpl:15
+	qw422016.N().D(len(d.RemovedEdges))
+//line diff.qtpl:15
+	qw422016.N().S(`|`)
+//line diff.qtpl:15
+	qw422016.N().S(`
+`)
+//line diff.qtpl:15
+	qw422016.N().S(`| New third-party packages |`)
+//line diff.qtpl:16
+	qw422016.N().D(len(d.AddedThirdParty))
+//line diff.qtpl:16
+	qw422016.N().S(`|`)
+//line diff.qtpl:16
+	qw422016.N().S(`
+`)
+//line diff.qtpl:16
+	qw422016.N().S(`| New cycles |`)
+//line diff.qtpl:17
+	qw422016.N().D(len(d.AddedCycles))
+//line diff.qtpl:17
+	qw422016.N().S(`|`)
+//line diff.qtpl:17
+	qw422016.N().S(`
+`)
+//line diff.qtpl:17
+	qw422016.N().S(`| Packages with API changes |`)
+//line diff.qtpl:18
+	qw422016.N().D(len(d.Exports))
+//line diff.qtpl:18
+	qw422016.N().S(`|`)
+//line diff.qtpl:18
+	qw422016.N().S(`
+`)
+//line diff.qtpl:19
+	if len(d.AddedCycles) > 0 {
+//line diff.qtpl:20
+		qw422016.N().S(`
+`)
+//line diff.qtpl:20
+		qw422016.N().S(`#### :warning: New cycles`)
+//line diff.qtpl:21
+		qw422016.N().S(`
+`)
+//line diff.qtpl:22
+		qw422016.N().S(`
+`)
+//line diff.qtpl:23
+		for _, c := range d.AddedCycles {
+//line diff.qtpl:23
+			qw422016.N().S(`-`)
+//line diff.qtpl:24
+			qw422016.N().S(` `)
+//line diff.qtpl:24
+			qw422016.N().S(`**`)
+//line diff.qtpl:24
+			qw422016.E().S(c.Kind.String())
+//line diff.qtpl:24
+			qw422016.N().S(`**:`)
+//line diff.qtpl:24
+			qw422016.N().S(` `)
+//line diff.qtpl:25
+			for i, p := range c.Path {
+//line diff.qtpl:26
+				if i > 0 {
+//line diff.qtpl:26
+					qw422016.N().S(` `)
+//line diff.qtpl:26
+					qw422016.N().S(`&rarr;`)
+//line diff.qtpl:26
+					qw422016.N().S(` `)
+//line diff.qtpl:26
+				}
+//line diff.qtpl:26
+				qw422016.N().S(``)
+//line diff.qtpl:26
+				qw422016.N().S("`")
+//line diff.qtpl:27
+				qw422016.N().S(p)
+//line diff.qtpl:27
+				qw422016.N().S(``)
+//line diff.qtpl:27
+				qw422016.N().S("`")
+//line diff.qtpl:28
+			}
+//line diff.qtpl:29
+			qw422016.N().S(`
+`)
+//line diff.qtpl:30
+		}
+//line diff.qtpl:31
+	}
+//line diff.qtpl:32
+	if len(d.AddedThirdParty) > 0 {
+//line diff.qtpl:33
+		qw422016.N().S(`
+`)
+//line diff.qtpl:33
+		qw422016.N().S(`#### New third-party packages`)
+//line diff.qtpl:34
+		qw422016.N().S(`
+`)
+//line diff.qtpl:35
+		qw422016.N().S(`
+`)
+//line diff.qtpl:36
+		for _, p := range d.AddedThirdParty {
+//line diff.qtpl:36
+			qw422016.N().S(`-`)
+//line diff.qtpl:37
+			qw422016.N().S(` `)
+//line diff.qtpl:37
+			qw422016.N().S(``)
+//line diff.qtpl:37
+			qw422016.N().S("`")
+//line diff.qtpl:37
+			qw422016.N().S(p)
+//line diff.qtpl:37
+			qw422016.N().S(``)
+//line diff.qtpl:37
+			qw422016.N().S("`")
+//line diff.qtpl:37
+			qw422016.N().S(`
+`)
+//line diff.qtpl:38
+		}
+//line diff.qtpl:39
+	}
+//line diff.qtpl:40
+	streammarkdownList(qw422016, "Added packages", d.AddedPackages)
+//line diff.qtpl:41
+	streammarkdownList(qw422016, "Removed packages", d.RemovedPackages)
+//line diff.qtpl:42
+	streammarkdownEdges(qw422016, "Added imports", d.AddedEdges)
+//line diff.qtpl:43
+	streammarkdownEdges(qw422016, "Removed imports", d.RemovedEdges)
+//line diff.qtpl:44
+	if len(d.Exports) > 0 {
+//line diff.qtpl:45
+		qw422016.N().S(`
+`)
+//line diff.qtpl:45
+		qw422016.N().S(`#### API changes`)
+//line diff.qtpl:46
+		qw422016.N().S(`
+`)
+//line diff.qtpl:47
+		for _, e := range d.Exports {
+//line diff.qtpl:48
+			qw422016.N().S(`
+`)
+//line diff.qtpl:48
+			qw422016.N().S(`<details><summary><code>`)
+//line diff.qtpl:49
+			qw422016.E().S(e.Package)
+//line diff.qtpl:49
+			qw422016.N().S(`</code></summary>`)
+//line diff.qtpl:49
+			qw422016.N().S(`
+`)
+//line diff.qtpl:50
+			qw422016.N().S(`
+`)
+//line diff.qtpl:50
+			qw422016.N().S(``)
+//line diff.qtpl:50
+			qw422016.N().S("`")
+//line diff.qtpl:50
+			qw422016.N().S(``)
+//line diff.qtpl:50
+			qw422016.N().S("`")
+//line diff.qtpl:50
+			qw422016.N().S(``)
+//line diff.qtpl:50
+			qw422016.N().S("`")
+//line diff.qtpl:50
+			qw422016.N().S(`diff`)
+//line diff.qtpl:51
+			qw422016.N().S(`
+`)
+//line diff.qtpl:52
+			streammarkdownDiffLines(qw422016, "-", "func", e.RemovedFunctions)
+//line diff.qtpl:53
+			streammarkdownDiffLines(qw422016, "+", "func", e.AddedFunctions)
+//line diff.qtpl:54
+			streammarkdownDiffLines(qw422016, "-", "type", e.RemovedTypes)
+//line diff.qtpl:55
+			streammarkdownDiffLines(qw422016, "+", "type", e.AddedTypes)
+//line diff.qtpl:56
+			streammarkdownDiffLines(qw422016, "-", "", e.RemovedMethods)
+//line diff.qtpl:57
+			streammarkdownDiffLines(qw422016, "+", "", e.AddedMethods)
+//line diff.qtpl:58
+			streammarkdownDiffLines(qw422016, "-", "field", e.RemovedFields)
+//line diff.qtpl:59
+			streammarkdownDiffLines(qw422016, "+", "field", e.AddedFields)
+//line diff.qtpl:60
+			streammarkdownDiffLines(qw422016, "-", "const", e.RemovedConstants)
+//line diff.qtpl:61
+			streammarkdownDiffLines(qw422016, "+", "const", e.AddedConstants)
+//line diff.qtpl:62
+			streammarkdownDiffLines(qw422016, "-", "var", e.RemovedVariables)
+//line diff.qtpl:63
+			streammarkdownDiffLines(qw422016, "+", "var", e.AddedVariables)
+//line diff.qtpl:63
+			qw422016.N().S(``)
+//line diff.qtpl:63
+			qw422016.N().S("`")
+//line diff.qtpl:63
+			qw422016.N().S(``)
+//line diff.qtpl:63
+			qw422016.N().S("`")
+//line diff.qtpl:63
+			qw422016.N().S(``)
+//line diff.qtpl:63
+			qw422016.N().S("`")
+//line diff.qtpl:64
+			qw422016.N().S(`
+`)
+//line diff.qtpl:64
+			qw422016.N().S(`</details>`)
+//line diff.qtpl:65
+			qw422016.N().S(`
+`)
+//line diff.qtpl:66
+		}
+//line diff.qtpl:67
+	}
+//line diff.qtpl:68
+}
+
+//line diff.qtpl:68
+func (d *Diff) WriteMarkdown(qq422016 qtio422016.Writer) {
+//line diff.qtpl:68
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line diff.qtpl:68
+	d.StreamMarkdown(qw422016)
+//line diff.qtpl:68
+	qt422016.ReleaseWriter(qw422016)
+//line diff.qtpl:68
+}
+
+//line diff.qtpl:68
+func (d *Diff) Markdown() string {
+//line diff.qtpl:68
+	qb422016 := qt422016.AcquireByteBuffer()
+//line diff.qtpl:68
+	d.WriteMarkdown(qb422016)
+//line diff.qtpl:68
+	qs422016 := string(qb422016.B)
+//line diff.qtpl:68
+	qt422016.ReleaseByteBuffer(qb422016)
+//line diff.qtpl:68
+	return qs422016
+//line diff.qtpl:68
+}
+
+//line diff.qtpl:70
+func streammarkdownList(qw422016 *qt422016.Writer, title string, items []string) {
+//line diff.qtpl:71
+	if len(items) > 0 {
+//line diff.qtpl:72
+		qw422016.N().S(`
+`)
+//line diff.qtpl:72
+		qw422016.N().S(`<details><summary>`)
+//line diff.qtpl:73
+		qw422016.E().S(title)
+//line diff.qtpl:73
+		qw422016.N().S(` `)
+//line diff.qtpl:73
+		qw422016.N().S(`(`)
+//line diff.qtpl:73
+		qw422016.N().D(len(items))
+//line diff.qtpl:73
+		qw422016.N().S(`)</summary>`)
+//line diff.qtpl:73
+		qw422016.N().S(`
+`)
+//line diff.qtpl:74
+		qw422016.N().S(`
+`)
+//line diff.qtpl:75
+		for _, i := range items {
+//line diff.qtpl:75
+			qw422016.N().S(`-`)
+//line diff.qtpl:76
+			qw422016.N().S(` `)
+//line diff.qtpl:76
+			qw422016.N().S(``)
+//line diff.qtpl:76
+			qw422016.N().S("`")
+//line diff.qtpl:76
+			qw422016.N().S(i)
+//line diff.qtpl:76
+			qw422016.N().S(``)
+//line diff.qtpl:76
+			qw422016.N().S("`")
+//line diff.qtpl:76
+			qw422016.N().S(`
+`)
+//line diff.qtpl:77
+		}
+//line diff.qtpl:78
+		qw422016.N().S(`
+`)
+//line diff.qtpl:78
+		qw422016.N().S(`</details>`)
+//line diff.qtpl:79
+		qw422016.N().S(`
+`)
+//line diff.qtpl:80
+	}
+//line diff.qtpl:81
+}
+
+//line diff.qtpl:81
+func writemarkdownList(qq422016 qtio422016.Writer, title string, items []string) {
+//line diff.qtpl:81
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line diff.qtpl:81
+	streammarkdownList(qw422016, title, items)
+//line diff.qtpl:81
+	qt422016.ReleaseWriter(qw422016)
+//line diff.qtpl:81
+}
+
+//line diff.qtpl:81
+func markdownList(title string, items []string) string {
+//line diff.qtpl:81
+	qb422016 := qt422016.AcquireByteBuffer()
+//line diff.qtpl:81
+	writemarkdownList(qb422016, title, items)
+//line diff.qtpl:81
+	qs422016 := string(qb422016.B)
+//line diff.qtpl:81
+	qt422016.ReleaseByteBuffer(qb422016)
+//line diff.qtpl:81
+	return qs422016
+//line diff.qtpl:81
+}
+
+//line diff.qtpl:83
+func streammarkdownEdges(qw422016 *qt422016.Writer, title string, edges []Edge) {
+//line diff.qtpl:84
+	if len(edges) > 0 {
+//line diff.qtpl:85
+		qw422016.N().S(`
+`)
+//line diff.qtpl:85
+		qw422016.N().S(`<details><summary>`)
+//line diff.qtpl:86
+		qw422016.E().S(title)
+//line diff.qtpl:86
+		qw422016.N().S(` `)
+//line diff.qtpl:86
+		qw422016.N().S(`(`)
+//line diff.qtpl:86
+		qw422016.N().D(len(edges))
+//line diff.qtpl:86
+		qw422016.N().S(`)</summary>`)
+//line diff.qtpl:86
+		qw422016.N().S(`
+`)
+//line diff.qtpl:87
+		qw422016.N().S(`
+`)
+//line diff.qtpl:88
+		for _, e := range edges {
+//line diff.qtpl:88
+			qw422016.N().S(`-`)
+//line diff.qtpl:89
+			qw422016.N().S(` `)
+//line diff.qtpl:89
+			qw422016.N().S(``)
+//line diff.qtpl:89
+			qw422016.N().S("`")
+//line diff.qtpl:89
+			qw422016.N().S(e.From)
+//line diff.qtpl:89
+			qw422016.N().S(``)
+//line diff.qtpl:89
+			qw422016.N().S("`")
+//line diff.qtpl:89
+			qw422016.N().S(` `)
+//line diff.qtpl:89
+			qw422016.N().S(`&rarr;`)
+//line diff.qtpl:89
+			qw422016.N().S(` `)
+//line diff.qtpl:89
+			qw422016.N().S(``)
+//line diff.qtpl:89
+			qw422016.N().S("`")
+//line diff.qtpl:89
+			qw422016.N().S(e.To)
+//line diff.qtpl:89
+			qw422016.N().S(``)
+//line diff.qtpl:89
+			qw422016.N().S("`")
+//line diff.qtpl:89
+			qw422016.N().S(`
+`)
+//line diff.qtpl:90
+		}
+//line diff.qtpl:91
+		qw422016.N().S(`
+`)
+//line diff.qtpl:91
+		qw422016.N().S(`</details>`)
+//line diff.qtpl:92
+		qw422016.N().S(`
+`)
+//line diff.qtpl:93
+	}
+//line diff.qtpl:94
+}
+
+//line diff.qtpl:94
+func writemarkdownEdges(qq422016 qtio422016.Writer, title string, edges []Edge) {
+//line diff.qtpl:94
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line diff.qtpl:94
+	streammarkdownEdges(qw422016, title, edges)
+//line diff.qtpl:94
+	qt422016.ReleaseWriter(qw422016)
+//line diff.qtpl:94
+}
+
+//line diff.qtpl:94
+func markdownEdges(title string, edges []Edge) string {
+//line diff.qtpl:94
+	qb422016 := qt422016.AcquireByteBuffer()
+//line diff.qtpl:94
+	writemarkdownEdges(qb422016, title, edges)
+//line diff.qtpl:94
+	qs422016 := string(qb422016.B)
+//line diff.qtpl:94
+	qt422016.ReleaseByteBuffer(qb422016)
+//line diff.qtpl:94
+	return qs422016
+//line diff.qtpl:94
+}
+
+//line diff.qtpl:96
+func streammarkdownDiffLines(qw422016 *qt422016.Writer, sign, kind string, items []string) {
+//line diff.qtpl:97
+	for _, i := range items {
+//line diff.qtpl:98
+		qw422016.N().S(sign)
+//line diff.qtpl:98
+		qw422016.N().S(` `)
+//line diff.qtpl:99
+		if kind != "" {
+//line diff.qtpl:100
+			qw422016.N().S(kind)
+//line diff.qtpl:100
+			qw422016.N().S(` `)
+//line diff.qtpl:101
+		}
+//line diff.qtpl:102
+		qw422016.N().S(i)
+//line diff.qtpl:102
+		qw422016.N().S(`
+`)
+//line diff.qtpl:103
+	}
+//line diff.qtpl:104
+}
+
+//line diff.qtpl:104
+func writemarkdownDiffLines(qq422016 qtio422016.Writer, sign, kind string, items []string) {
+//line diff.qtpl:104
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line diff.qtpl:104
+	streammarkdownDiffLines(qw422016, sign, kind, items)
+//line diff.qtpl:104
+	qt422016.ReleaseWriter(qw422016)
+//line diff.qtpl:104
+}
+
+//line diff.qtpl:104
+func markdownDiffLines(sign, kind string, items []string) string {
+//line diff.qtpl:104
+	qb422016 := qt422016.AcquireByteBuffer()
+//line diff.qtpl:104
+	writemarkdownDiffLines(qb422016, sign, kind, items)
+//line diff.qtpl:104
+	qs422016 := string(qb422016.B)
+//line diff.qtpl:104
+	qt422016.ReleaseByteBuffer(qb422016)
+//line diff.qtpl:104
+	return qs422016
+//line diff.qtpl:104
+}
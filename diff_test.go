@@ -0,0 +1,38 @@
+package godeep
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiffMarkdownKeepsSignatures(t *testing.T) {
+	oldPackages, newPackages := InitPackages(), InitPackages()
+	err := oldPackages.Unmarshal([]byte(`{"version": 1, "packages": [
+		{"name": "b", "path": "example.com/a/b", "exportedFunctions": ["Watch() <-chan int"]}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = newPackages.Unmarshal([]byte(`{"version": 1, "packages": [
+		{"name": "b", "path": "example.com/a/b", "imported": ["example.com/x<y"],
+		 "exportedFunctions": ["Watch(filter map[string]bool) <-chan int"],
+		 "exportedTypes": [{"name": "Event", "kind": "struct", "fields": [{"name": "ID", "type": "int", "tag": "json:\"id\""}]}]}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	md := Compare(oldPackages, newPackages).Markdown()
+	for _, expected := range []string{
+		"- func Watch() <-chan int\n",
+		"+ func Watch(filter map[string]bool) <-chan int\n",
+		"+ field Event.ID int `json:\"id\"`\n",
+		"`example.com/a/b` &rarr; `example.com/x<y`",
+	} {
+		if !strings.Contains(md, expected) {
+			t.Errorf("expected %q in the markdown:\n%s", expected, md)
+		}
+	}
+	if strings.Contains(md, "&lt;") || strings.Contains(md, "&quot;") {
+		t.Errorf("code spans must not be HTML escaped:\n%s", md)
+	}
+}
@@ -0,0 +1,238 @@
+// Code generated by qtc from "dot.qtpl". DO NOT EDIT.
+// See https://github.com/valyala/quicktemplate for details.
+
+// Graphviz DOT rendering of the import graph
+
+//line dot.qtpl:3
+package godeep
+
+//line dot.qtpl:3
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line dot.qtpl:3
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+//line dot.qtpl:3
+func (d *diagram) StreamDOT(qw422016 *qt422016.Writer) {
+//line dot.qtpl:3
+	qw422016.N().S(`digraph godeep {`)
+//line dot.qtpl:4
+	qw422016.N().S(`
+`)
+//line dot.qtpl:4
+	qw422016.N().S(`rankdir=LR;`)
+//line dot.qtpl:5
+	qw422016.N().S(`
+`)
+//line dot.qtpl:5
+	qw422016.N().S(`node [shape=box, style="rounded,filled", fontname="Helvetica"];`)
+//line dot.qtpl:6
+	qw422016.N().S(`
+`)
+//line dot.qtpl:7
+	for i, c := range d.Clusters {
+//line dot.qtpl:8
+		if c.Name == "" {
+//line dot.qtpl:9
+			for _, n := range c.Nodes {
+//line dot.qtpl:10
+				streamdotNode(qw422016, n)
+//line dot.qtpl:10
+				qw422016.N().S(`
+`)
+//line dot.qtpl:11
+			}
+//line dot.qtpl:12
+		} else {
+//line dot.qtpl:12
+			qw422016.N().S(`subgraph cluster_`)
+//line dot.qtpl:13
+			qw422016.N().D(i)
+//line dot.qtpl:13
+			qw422016.N().S(` `)
+//line dot.qtpl:13
+			qw422016.N().S(`{`)
+//line dot.qtpl:13
+			qw422016.N().S(`
+`)
+//line dot.qtpl:13
+			qw422016.N().S(`label=`)
+//line dot.qtpl:14
+			qw422016.N().Q(c.Name)
+//line dot.qtpl:14
+			qw422016.N().S(`;`)
+//line dot.qtpl:14
+			qw422016.N().S(`
+`)
+//line dot.qtpl:15
+			for _, n := range c.Nodes {
+//line dot.qtpl:16
+				streamdotNode(qw422016, n)
+//line dot.qtpl:16
+				qw422016.N().S(`
+`)
+//line dot.qtpl:17
+			}
+//line dot.qtpl:17
+			qw422016.N().S(`}`)
+//line dot.qtpl:18
+			qw422016.N().S(`
+`)
+//line dot.qtpl:19
+		}
+//line dot.qtpl:20
+	}
+//line dot.qtpl:21
+	for _, e := range d.Edges {
+//line dot.qtpl:22
+		qw422016.N().Q(e.From)
+//line dot.qtpl:22
+		qw422016.N().S(` `)
+//line dot.qtpl:22
+		qw422016.N().S(`->`)
+//line dot.qtpl:22
+		qw422016.N().S(` `)
+//line dot.qtpl:22
+		qw422016.N().Q(e.To)
+//line dot.qtpl:23
+		if e.Cycle || e.Weight > 0 {
+//line dot.qtpl:24
+			qw422016.N().S(` `)
+//line dot.qtpl:24
+			qw422016.N().S(`[`)
+//line dot.qtpl:25
+			if e.Cycle {
+//line dot.qtpl:25
+				qw422016.N().S(`color="#DE350B", penwidth=2`)
+//line dot.qtpl:27
+				if e.Weight > 0 {
+//line dot.qtpl:27
+					qw422016.N().S(`,`)
+//line dot.qtpl:28
+					qw422016.N().S(` `)
+//line dot.qtpl:29
+				}
+//line dot.qtpl:30
+			}
+//line dot.qtpl:31
+			if e.Weight > 0 {
+//line dot.qtpl:31
+				qw422016.N().S(`label="`)
+//line dot.qtpl:32
+				qw422016.N().D(e.Weight)
+//line dot.qtpl:32
+				qw422016.N().S(`"`)
+//line dot.qtpl:33
+			}
+//line dot.qtpl:33
+			qw422016.N().S(`]`)
+//line dot.qtpl:35
+		}
+//line dot.qtpl:35
+		qw422016.N().S(`;`)
+//line dot.qtpl:36
+		qw422016.N().S(`
+`)
+//line dot.qtpl:37
+	}
+//line dot.qtpl:37
+	qw422016.N().S(`}`)
+//line dot.qtpl:38
+	qw422016.N().S(`
+`)
+//line dot.qtpl:39
+}
+
+//line dot.qtpl:39
+func (d *diagram) WriteDOT(qq422016 qtio422016.Writer) {
+//line dot.qtpl:39
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line dot.qtpl:39
+	d.StreamDOT(qw422016)
+//line dot.qtpl:39
+	qt422016.ReleaseWriter(qw422016)
+//line dot.qtpl:39
+}
+
+//line dot.qtpl:39
+func (d *diagram) DOT() string {
+//line dot.qtpl:39
+	qb422016 := qt422016.AcquireByteBuffer()
+//line dot.qtpl:39
+	d.WriteDOT(qb422016)
+//line dot.qtpl:39
+	qs422016 := string(qb422016.B)
+//line dot.qtpl:39
+	qt422016.ReleaseByteBuffer(qb422016)
+//line dot.qtpl:39
+	return qs422016
+//line dot.qtpl:39
+}
+
+//line dot.qtpl:41
+func streamdotNode(qw422016 *qt422016.Writer, n diagramNode) {
+//line dot.qtpl:42
+	qw422016.N().Q(n.Path)
+//line dot.qtpl:42
+	qw422016.N().S(` `)
+//line dot.qtpl:42
+	qw422016.N().S(`[`)
+//line dot.qtpl:43
+	switch n.Kind {
+//line dot.qtpl:44
+	case NodeInternal:
+//line dot.qtpl:44
+		qw422016.N().S(`fillcolor="#B3D4FF"`)
+//line dot.qtpl:46
+	case NodeThirdParty:
+//line dot.qtpl:46
+		qw422016.N().S(`fillcolor="#FFE380"`)
+//line dot.qtpl:48
+	case NodeStd:
+//line dot.qtpl:48
+		qw422016.N().S(`fillcolor="#EBECF0"`)
+//line dot.qtpl:50
+	}
+//line dot.qtpl:51
+	if n.Broken {
+//line dot.qtpl:51
+		qw422016.N().S(`, color="#DE350B", penwidth=2, style="rounded,filled,dashed"`)
+//line dot.qtpl:53
+	}
+//line dot.qtpl:53
+	qw422016.N().S(`];`)
+//line dot.qtpl:55
+}
+
+//line dot.qtpl:55
+func writedotNode(qq422016 qtio422016.Writer, n diagramNode) {
+//line dot.qtpl:55
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line dot.qtpl:55
+	streamdotNode(qw422016, n)
+//line dot.qtpl:55
+	qt422016.ReleaseWriter(qw422016)
+//line dot.qtpl:55
+}
+
+//line dot.qtpl:55
+func dotNode(n diagramNode) string {
+//line dot.qtpl:55
+	qb422016 := qt422016.AcquireByteBuffer()
+//line dot.qtpl:55
+	writedotNode(qb422016, n)
+//line dot.qtpl:55
+	qs422016 := string(qb422016.B)
+//line dot.qtpl:55
+	qt422016.ReleaseByteBuffer(qb422016)
+//line dot.qtpl:55
+	return qs422016
+//line dot.qtpl:55
+}
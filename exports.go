@@ -0,0 +1,330 @@
+package godeep
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+)
+
+type TypeKind int
+
+const (
+	TypeUnknown TypeKind = iota
+	TypeStruct
+	TypeInterface
+	TypeAlias
+	TypeBasic
+	TypeFunc
+	// TypeOther are the named pointers, slices, arrays, maps and channels
+	TypeOther
+)
+
+func (k TypeKind) String() string {
+	switch k {
+	case TypeStruct:
+		return "struct"
+	case TypeInterface:
+		return "interface"
+	case TypeAlias:
+		return "alias"
+	case TypeBasic:
+		return "basic"
+	case TypeFunc:
+		return "func"
+	case TypeOther:
+		return "other"
+	}
+	return "unknown"
+}
+
+func (k TypeKind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+func (k *TypeKind) UnmarshalText(text []byte) error {
+	for *k = TypeStruct; *k <= TypeOther; *k++ {
+		if k.String() == string(text) {
+			return nil
+		}
+	}
+	*k = TypeUnknown
+	return nil
+}
+
+// Type is an exported type of a package. Methods are the exported methods of the method set of
+// the type, or the exported methods of the interface if the type is an interface. Underlying is
+// the aliased type of the aliases and the underlying type of the types which are neither structs
+// nor interfaces, i.e. '[]string' for 'type Names []string'.
+type Type struct {
+	Name       string      `json:"name"`
+	TypeParams []TypeParam `json:"typeParams,omitempty"`
+	Kind       TypeKind    `json:"kind,omitempty"`
+	Underlying string      `json:"underlying,omitempty"`
+	Fields     []Field     `json:"fields,omitempty"`
+	Embedded   []string    `json:"embedded,omitempty"`
+	Methods    []Method    `json:"methods,omitempty"`
+}
+
+// String returns the type as it is declared without its body, i.e. 'Client struct',
+// 'Names []string' or 'Reader = io.Reader'.
+func (t Type) String() string {
+	name := t.Name + typeParamsString(t.TypeParams)
+	switch t.Kind {
+	case TypeAlias:
+		return fmt.Sprintf("%s = %s", name, t.Underlying)
+	case TypeStruct, TypeInterface:
+		return fmt.Sprintf("%s %s", name, t.Kind)
+	case TypeUnknown:
+		return name
+	}
+	return fmt.Sprintf("%s %s", name, t.Underlying)
+}
+
+// MethodString returns the declaration of the method of the type, the interface methods have
+// no receiver and are prefixed by the interface name, i.e. 'Reader.Read(p []byte) (int, error)'.
+func (t Type) MethodString(m Method) string {
+	if t.Kind == TypeInterface {
+		return fmt.Sprintf("%s.%s%s", t.Name, m.Name, m.Signature)
+	}
+	return m.String(t.receiverName())
+}
+
+// receiverName returns the name of the type as it is used by the receivers, i.e. 'List[T]'.
+func (t Type) receiverName() string {
+	if len(t.TypeParams) == 0 {
+		return t.Name
+	}
+	names := make([]string, 0, len(t.TypeParams))
+	for _, tp := range t.TypeParams {
+		names = append(names, tp.Name)
+	}
+	return fmt.Sprintf("%s[%s]", t.Name, strings.Join(names, ", "))
+}
+
+// Field is an exported field of a struct, Tag is the raw struct tag.
+type Field struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Tag  string `json:"tag,omitempty"`
+}
+
+func (f Field) String() string {
+	if f.Tag == "" {
+		return fmt.Sprintf("%s %s", f.Name, f.Type)
+	}
+	return fmt.Sprintf("%s %s `%s`", f.Name, f.Type, f.Tag)
+}
+
+// Method is an exported method in the method set of a type, including the methods promoted from
+// the embedded fields. Pointer is set if the method is only in the method set of the pointer
+// type, i.e. it has a pointer receiver.
+type Method struct {
+	Name      string `json:"name"`
+	Signature string `json:"signature"`
+	Pointer   bool   `json:"pointer,omitempty"`
+}
+
+// String returns the method as it is declared, i.e. 'func (*Client) Do(req *Request) error'.
+func (m Method) String(typeName string) string {
+	if m.Pointer {
+		typeName = "*" + typeName
+	}
+	return fmt.Sprintf("func (%s) %s%s", typeName, m.Name, m.Signature)
+}
+
+func newType(o *types.TypeName, qualifier types.Qualifier) Type {
+	t := Type{
+		Name: o.Name(),
+	}
+	if named, ok := o.Type().(*types.Named); ok {
+		t.TypeParams = typeParams(named.TypeParams(), qualifier)
+	}
+	if o.IsAlias() {
+		t.Kind = TypeAlias
+		t.Underlying = types.TypeString(types.Unalias(o.Type()), qualifier)
+		return t
+	}
+	switch u := o.Type().Underlying().(type) {
+	case *types.Struct:
+		t.Kind = TypeStruct
+		for i := 0; i < u.NumFields(); i++ {
+			f := u.Field(i)
+			switch {
+			case f.Embedded():
+				t.Embedded = append(t.Embedded, types.TypeString(f.Type(), qualifier))
+			case f.Exported():
+				t.Fields = append(t.Fields, Field{
+					Name: f.Name(),
+					Type: types.TypeString(f.Type(), qualifier),
+					Tag:  u.Tag(i),
+				})
+			}
+		}
+	case *types.Interface:
+		t.Kind = TypeInterface
+		for i := 0; i < u.NumEmbeddeds(); i++ {
+			t.Embedded = append(t.Embedded, types.TypeString(u.EmbeddedType(i), qualifier))
+		}
+		for i := 0; i < u.NumMethods(); i++ {
+			if fn := u.Method(i); fn.Exported() {
+				t.Methods = append(t.Methods, Method{
+					Name:      fn.Name(),
+					Signature: signatureString(fn.Type().(*types.Signature), qualifier),
+				})
+			}
+		}
+		return t
+	case *types.Basic:
+		t.Kind = TypeBasic
+		t.Underlying = u.String()
+	case *types.Signature:
+		t.Kind = TypeFunc
+		t.Underlying = "func" + signatureString(u, qualifier)
+	default:
+		t.Kind = TypeOther
+		t.Underlying = types.TypeString(u, qualifier)
+	}
+
+	valueMethods := types.NewMethodSet(o.Type())
+	pointerMethods := types.NewMethodSet(types.NewPointer(o.Type()))
+	for i := 0; i < pointerMethods.Len(); i++ {
+		fn, ok := pointerMethods.At(i).Obj().(*types.Func)
+		if !ok || !fn.Exported() {
+			continue
+		}
+		t.Methods = append(t.Methods, Method{
+			Name:      fn.Name(),
+			Signature: signatureString(fn.Type().(*types.Signature), qualifier),
+			Pointer:   valueMethods.Lookup(fn.Pkg(), fn.Name()) == nil,
+		})
+	}
+	sort.Slice(t.Methods, func(i, j int) bool {
+		return t.Methods[i].Name < t.Methods[j].Name
+	})
+	return t
+}
+
+// Value is an exported constant or variable of a package, Value is only set for the constants
+// and is the exact value of the constant, i.e. '"json"' or '3'.
+type Value struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Value string `json:"value,omitempty"`
+}
+
+func (v Value) String() string {
+	if v.Value == "" {
+		return fmt.Sprintf("%s %s", v.Name, v.Type)
+	}
+	return fmt.Sprintf("%s %s = %s", v.Name, v.Type, v.Value)
+}
+
+func newValue(o types.Object, qualifier types.Qualifier) Value {
+	v := Value{
+		Name: o.Name(),
+		Type: types.TypeString(o.Type(), qualifier),
+	}
+	if c, ok := o.(*types.Const); ok {
+		v.Value = c.Val().ExactString()
+	}
+	return v
+}
+
+// Enum is a named type of a package with the exported constants of that type which are declared
+// in const blocks using iota. Values are in the order of their declaration.
+type Enum struct {
+	Type   string   `json:"type"`
+	Values []string `json:"values"`
+}
+
+// enums returns the enumerations declared in the files of the package, sorted by their type.
+func enums(pkg *types.Package, files []*ast.File) []Enum {
+	var (
+		res   []Enum
+		index = map[string]int{}
+	)
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			d, ok := decl.(*ast.GenDecl)
+			if !ok || d.Tok != token.CONST || !usesIota(d) {
+				continue
+			}
+			for _, spec := range d.Specs {
+				for _, name := range spec.(*ast.ValueSpec).Names {
+					c, ok := pkg.Scope().Lookup(name.Name).(*types.Const)
+					if !ok || !c.Exported() {
+						continue
+					}
+					named, ok := c.Type().(*types.Named)
+					if !ok || named.Obj().Pkg() != pkg {
+						continue
+					}
+					typeName := named.Obj().Name()
+					idx, ok := index[typeName]
+					if !ok {
+						idx = len(res)
+						index[typeName] = idx
+						res = append(res, Enum{Type: typeName})
+					}
+					res[idx].Values = append(res[idx].Values, c.Name())
+				}
+			}
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Type < res[j].Type
+	})
+	return res
+}
+
+func usesIota(d *ast.GenDecl) bool {
+	found := false
+	ast.Inspect(d, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && ident.Name == "iota" {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+func valueStrings(values []Value) []string {
+	res := make([]string, 0, len(values))
+	for _, v := range values {
+		res = append(res, v.String())
+	}
+	return res
+}
+
+func typeStrings(typs []Type) []string {
+	res := make([]string, 0, len(typs))
+	for _, t := range typs {
+		res = append(res, t.String())
+	}
+	return res
+}
+
+// methodStrings returns the declarations of the methods of all the types.
+func methodStrings(typs []Type) []string {
+	var res []string
+	for _, t := range typs {
+		for _, m := range t.Methods {
+			res = append(res, t.MethodString(m))
+		}
+	}
+	return res
+}
+
+// fieldStrings returns the fields of all the structs, prefixed by the name of their struct.
+func fieldStrings(typs []Type) []string {
+	var res []string
+	for _, t := range typs {
+		for _, f := range t.Fields {
+			res = append(res, fmt.Sprintf("%s.%s", t.Name, f))
+		}
+	}
+	return res
+}
@@ -0,0 +1,155 @@
+package godeep
+
+import (
+	"fmt"
+	"go/types"
+	"golang.org/x/tools/go/packages"
+	"sort"
+	"strings"
+)
+
+type TypeParam struct {
+	Name       string `json:"name"`
+	Constraint string `json:"constraint"`
+}
+
+// Generic is an exported generic function or type of a package.
+type Generic struct {
+	Name       string      `json:"name"`
+	Func       bool        `json:"func,omitempty"`
+	TypeParams []TypeParam `json:"typeParams"`
+}
+
+func (g Generic) String() string {
+	return g.Name + typeParamsString(g.TypeParams)
+}
+
+// Instantiation is the use of a generic function or type with a list of type arguments, which
+// is recorded in the package which instantiates it. Package is the path of the package of the
+// generic and Count is the number of the instantiations with the same type arguments.
+type Instantiation struct {
+	Package  string   `json:"package"`
+	Name     string   `json:"name"`
+	TypeArgs []string `json:"typeArgs"`
+	Count    int      `json:"count"`
+}
+
+// GenericUsage is an exported generic of an analyzed package and the packages instantiating it.
+type GenericUsage struct {
+	Package string
+	Generic
+	Sites []InstantiationSite
+}
+
+type InstantiationSite struct {
+	Package  string
+	TypeArgs []string
+	Count    int
+}
+
+// Generics returns the exported generics of the analyzed packages and their instantiations,
+// sorted by package and name. If pkgPath is not empty only the generics of that package are
+// returned.
+func (a *Packages) Generics(pkgPath string) []GenericUsage {
+	a.mtx.RLock()
+	defer a.mtx.RUnlock()
+	var res []GenericUsage
+	index := map[string]int{}
+	for _, path := range keys(a.byPath) {
+		if pkgPath != "" && path != pkgPath {
+			continue
+		}
+		for _, g := range a.byPath[path].generics {
+			index[path+"."+g.Name] = len(res)
+			res = append(res, GenericUsage{Package: path, Generic: g})
+		}
+	}
+	for _, path := range keys(a.byPath) {
+		for _, i := range a.byPath[path].instantiations {
+			idx, ok := index[i.Package+"."+i.Name]
+			if !ok {
+				continue
+			}
+			res[idx].Sites = append(res[idx].Sites, InstantiationSite{
+				Package:  path,
+				TypeArgs: i.TypeArgs,
+				Count:    i.Count,
+			})
+		}
+	}
+	return res
+}
+
+func typeParams(list *types.TypeParamList, qualifier types.Qualifier) []TypeParam {
+	var res []TypeParam
+	for i := 0; i < list.Len(); i++ {
+		tp := list.At(i)
+		res = append(res, TypeParam{
+			Name:       tp.Obj().Name(),
+			Constraint: types.TypeString(tp.Constraint(), qualifier),
+		})
+	}
+	return res
+}
+
+func typeParamsString(tps []TypeParam) string {
+	if len(tps) == 0 {
+		return ""
+	}
+	parts := make([]string, 0, len(tps))
+	for _, tp := range tps {
+		parts = append(parts, fmt.Sprintf("%s %s", tp.Name, tp.Constraint))
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
+
+// instantiations returns the instantiations of the exported generics of the other non-standard
+// packages and the package itself, the type arguments are qualified by the package paths.
+func instantiations(pkg *packages.Package, std map[string]bool) []Instantiation {
+	if pkg.TypesInfo == nil {
+		return nil
+	}
+	var res []Instantiation
+	index := map[string]int{}
+	for ident, inst := range pkg.TypesInfo.Instances {
+		o := pkg.TypesInfo.Uses[ident]
+		if o == nil || o.Pkg() == nil || !o.Exported() || std[o.Pkg().Path()] {
+			continue
+		}
+		i := Instantiation{
+			Package: o.Pkg().Path(),
+			Name:    o.Name(),
+		}
+		generic := false
+		for j := 0; j < inst.TypeArgs.Len(); j++ {
+			arg := inst.TypeArgs.At(j)
+			if _, ok := arg.(*types.TypeParam); ok {
+				generic = true
+			}
+			i.TypeArgs = append(i.TypeArgs, types.TypeString(arg, nil))
+		}
+		// Instantiations by the type parameters, i.e. the receivers of the generic methods, are
+		// not concrete uses
+		if generic {
+			continue
+		}
+		key := fmt.Sprintf("%s.%s%v", i.Package, i.Name, i.TypeArgs)
+		if idx, ok := index[key]; ok {
+			res[idx].Count++
+			continue
+		}
+		i.Count = 1
+		index[key] = len(res)
+		res = append(res, i)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Package != res[j].Package {
+			return res[i].Package < res[j].Package
+		}
+		if res[i].Name != res[j].Name {
+			return res[i].Name < res[j].Name
+		}
+		return strings.Join(res[i].TypeArgs, ",") < strings.Join(res[j].TypeArgs, ",")
+	})
+	return res
+}
@@ -1,18 +1,26 @@
 module github.com/ronaksoft/godeep
 
-go 1.13
+go 1.26.0
 
 require (
-	github.com/blushft/go-diagrams v0.0.0-20201006005127-c78c821223d9 // indirect
 	github.com/c-bata/go-prompt v0.2.3
 	github.com/fatih/color v1.9.0
-	github.com/gyuho/goraph v0.0.0-20171001060514-a7a4454fd3eb // indirect
-	github.com/klauspost/cpuid v1.2.0 // indirect
-	github.com/mattn/go-runewidth v0.0.8 // indirect
-	github.com/mattn/go-tty v0.0.3 // indirect
-	github.com/pkg/term v0.0.0-20190109203006-aa71e9d9e942 // indirect
 	github.com/spf13/cobra v0.0.5
 	github.com/spf13/pflag v1.0.5
 	github.com/valyala/quicktemplate v1.6.3
-	golang.org/x/tools v0.0.0-20200207183749-b753a1ba74fa
+	golang.org/x/mod v0.41.0
+	golang.org/x/tools v0.50.0
+	gopkg.in/yaml.v2 v2.4.0
+)
+
+require (
+	github.com/inconshreveable/mousetrap v1.0.0 // indirect
+	github.com/mattn/go-colorable v0.1.4 // indirect
+	github.com/mattn/go-isatty v0.0.11 // indirect
+	github.com/mattn/go-runewidth v0.0.8 // indirect
+	github.com/mattn/go-tty v0.0.3 // indirect
+	github.com/pkg/term v0.0.0-20190109203006-aa71e9d9e942 // indirect
+	github.com/valyala/bytebufferpool v1.0.0 // indirect
+	golang.org/x/sync v0.23.0 // indirect
+	golang.org/x/sys v0.48.0 // indirect
 )
@@ -0,0 +1,242 @@
+package godeep
+
+import (
+	"sort"
+)
+
+// graph is a simple directed graph keyed by node name, used by the analysis
+// queries which do not care whether nodes are packages, groups or modules.
+type graph map[string][]string
+
+func (g graph) addEdge(from, to string) {
+	for _, n := range g[from] {
+		if n == to {
+			return
+		}
+	}
+	g[from] = append(g[from], to)
+	if _, ok := g[to]; !ok {
+		g[to] = nil
+	}
+}
+
+func (g graph) nodes() []string {
+	nodes := make([]string, 0, len(g))
+	for n := range g {
+		nodes = append(nodes, n)
+	}
+	sort.Strings(nodes)
+	return nodes
+}
+
+// reverse returns a new graph with all the edges flipped.
+func (g graph) reverse() graph {
+	r := graph{}
+	for from, tos := range g {
+		if _, ok := r[from]; !ok {
+			r[from] = nil
+		}
+		for _, to := range tos {
+			r.addEdge(to, from)
+		}
+	}
+	return r
+}
+
+// scc returns the strongly connected components of the graph using Tarjan's
+// algorithm. Each component is sorted and the components are sorted by their
+// first member, so the result is deterministic.
+func (g graph) scc() [][]string {
+	var (
+		index   = 0
+		indices = make(map[string]int, len(g))
+		lowLink = make(map[string]int, len(g))
+		onStack = make(map[string]bool, len(g))
+		stack   []string
+		comps   [][]string
+	)
+	var connect func(n string)
+	connect = func(n string) {
+		indices[n] = index
+		lowLink[n] = index
+		index++
+		stack = append(stack, n)
+		onStack[n] = true
+		for _, m := range g[n] {
+			if _, ok := indices[m]; !ok {
+				connect(m)
+				if lowLink[m] < lowLink[n] {
+					lowLink[n] = lowLink[m]
+				}
+			} else if onStack[m] && indices[m] < lowLink[n] {
+				lowLink[n] = indices[m]
+			}
+		}
+		if lowLink[n] != indices[n] {
+			return
+		}
+		var comp []string
+		for {
+			m := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[m] = false
+			comp = append(comp, m)
+			if m == n {
+				break
+			}
+		}
+		sort.Strings(comp)
+		comps = append(comps, comp)
+	}
+	for _, n := range g.nodes() {
+		if _, ok := indices[n]; !ok {
+			connect(n)
+		}
+	}
+	sort.Slice(comps, func(i, j int) bool {
+		return comps[i][0] < comps[j][0]
+	})
+	return comps
+}
+
+// distances returns the distance of every node which is reachable from the node, including the
+// node itself. If transitive is not set, only the direct edges are followed.
+func (g graph) distances(from string, transitive bool) map[string]int {
+	distances := map[string]int{from: 0}
+	queue := []string{from}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		if !transitive && n != from {
+			break
+		}
+		for _, m := range g[n] {
+			if _, ok := distances[m]; ok {
+				continue
+			}
+			distances[m] = distances[n] + 1
+			queue = append(queue, m)
+		}
+	}
+	return distances
+}
+
+// shortestPath returns the shortest path from 'from' to 'to' using BFS. If skip is not nil,
+// the edges it returns true for are not traversed. If from equals to, the shortest cycle
+// through 'from' is returned. It returns nil if there is no path.
+func (g graph) shortestPath(from, to string, skip func(from, to string) bool) []string {
+	parent := map[string]string{}
+	visited := map[string]bool{}
+	queue := []string{from}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		for _, m := range g.sorted(n) {
+			if skip != nil && skip(n, m) {
+				continue
+			}
+			if m == to {
+				path := []string{to}
+				for p := n; p != from; p = parent[p] {
+					path = append(path, p)
+				}
+				path = append(path, from)
+				for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+					path[i], path[j] = path[j], path[i]
+				}
+				return path
+			}
+			if visited[m] || m == from {
+				continue
+			}
+			visited[m] = true
+			parent[m] = n
+			queue = append(queue, m)
+		}
+	}
+	return nil
+}
+
+func (g graph) sorted(n string) []string {
+	tos := append([]string(nil), g[n]...)
+	sort.Strings(tos)
+	return tos
+}
+
+// kShortestPaths returns at most k loopless paths from 'from' to 'to', ordered by length,
+// using Yen's algorithm.
+func (g graph) kShortestPaths(from, to string, k int) [][]string {
+	if from == to || k <= 0 {
+		return nil
+	}
+	first := g.shortestPath(from, to, nil)
+	if first == nil {
+		return nil
+	}
+	paths := [][]string{first}
+	var candidates [][]string
+	for len(paths) < k {
+		last := paths[len(paths)-1]
+		for i := 0; i < len(last)-1; i++ {
+			spur, root := last[i], last[:i+1]
+			removedEdges := map[[2]string]bool{}
+			for _, p := range paths {
+				if len(p) > i+1 && equalPaths(p[:i+1], root) {
+					removedEdges[[2]string{p[i], p[i+1]}] = true
+				}
+			}
+			removedNodes := map[string]bool{}
+			for _, n := range root[:i] {
+				removedNodes[n] = true
+			}
+			spurPath := g.shortestPath(spur, to, func(from, to string) bool {
+				return removedNodes[to] || removedEdges[[2]string{from, to}]
+			})
+			if spurPath == nil {
+				continue
+			}
+			candidate := append(append([]string(nil), root[:i]...), spurPath...)
+			if !containsPath(paths, candidate) && !containsPath(candidates, candidate) {
+				candidates = append(candidates, candidate)
+			}
+		}
+		if len(candidates) == 0 {
+			break
+		}
+		sort.SliceStable(candidates, func(i, j int) bool {
+			if len(candidates[i]) != len(candidates[j]) {
+				return len(candidates[i]) < len(candidates[j])
+			}
+			for idx := range candidates[i] {
+				if candidates[i][idx] != candidates[j][idx] {
+					return candidates[i][idx] < candidates[j][idx]
+				}
+			}
+			return false
+		})
+		paths = append(paths, candidates[0])
+		candidates = candidates[1:]
+	}
+	return paths
+}
+
+func equalPaths(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func containsPath(paths [][]string, path []string) bool {
+	for _, p := range paths {
+		if equalPaths(p, path) {
+			return true
+		}
+	}
+	return false
+}
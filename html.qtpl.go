@@ -0,0 +1,367 @@
+// Code generated by qtc from "html.qtpl". DO NOT EDIT.
+// See https://github.com/valyala/quicktemplate for details.
+
+// Self-contained interactive HTML report of the import graph
+
+//line html.qtpl:2
+package godeep
+
+//line html.qtpl:2
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line html.qtpl:2
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+//line html.qtpl:2
+func (r *htmlReport) StreamHTML(qw422016 *qt422016.Writer) {
+//line html.qtpl:2
+	qw422016.N().S(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>godeep report</title>
+<style>
+	html, body { margin: 0; height: 100%; font-family: Helvetica, Arial, sans-serif; font-size: 13px; }
+	#app { display: flex; height: 100%; }
+	#sidebar { width: 360px; display: flex; flex-direction: column; border-right: 1px solid #DFE1E6; }
+	#toolbar { padding: 8px; border-bottom: 1px solid #DFE1E6; }
+	#toolbar input[type=text] { width: 100%; box-sizing: border-box; padding: 4px; margin-bottom: 6px; }
+	#results { max-height: 30%; overflow: auto; border-bottom: 1px solid #DFE1E6; }
+	#results div, #details li.link { cursor: pointer; }
+	#results div { padding: 2px 8px; }
+	#results div:hover, #details li.link:hover { background: #F4F5F7; }
+	#details { flex: 1; overflow: auto; padding: 0 8px; }
+	#details h3 { margin: 8px 0 2px; font-size: 13px; }
+	#details ul { margin: 0; padding-left: 18px; }
+	#canvas { flex: 1; }
+	.legend span { display: inline-block; padding: 0 6px; margin-right: 4px; border-radius: 3px; }
+	.internal { background: #B3D4FF; }
+	.third-party { background: #FFE380; }
+	.std { background: #EBECF0; }
+	.cycle { color: #DE350B; }
+	svg text { font-size: 10px; pointer-events: none; }
+	svg .node rect { stroke: #7A869A; stroke-width: 1; cursor: pointer; }
+	svg .node rect.broken { stroke: #DE350B; stroke-width: 2; stroke-dasharray: 4 2; }
+	svg .node.selected rect { stroke: #0747A6; stroke-width: 3; }
+	svg .node.matched rect { stroke: #FF5630; stroke-width: 3; }
+	svg .node.dimmed, svg .edge.dimmed { opacity: 0.15; }
+	svg .edge { stroke: #A5ADBA; fill: none; }
+	svg .edge.cycle { stroke: #DE350B; stroke-width: 2.5; }
+</style>
+</head>
+<body>
+<div id="app">
+	<div id="sidebar">
+		<div id="toolbar">
+			<input id="search" type="text" placeholder="Search packages ...">
+			<label><input id="layout" type="checkbox"> hierarchical layout</label>
+			<label><input id="cycles" type="checkbox"> only cycles</label>
+			<div class="legend">
+				<span class="internal">internal</span><span class="third-party">third-party</span><span class="std">std</span>
+				<b class="cycle">&#8212; cycle</b> <b class="cycle">&#9633; errors</b>
+			</div>
+		</div>
+		<div id="results"></div>
+		<div id="details"><p>Click on a package to see its details.</p></div>
+	</div>
+	<svg id="canvas"><g id="viewport"><g id="edges"></g><g id="nodes"></g></g></svg>
+</div>
+<script id="data" type="application/json">`)
+//line html.qtpl:53
+	qw422016.N().Z(r.Data)
+//line html.qtpl:53
+	qw422016.N().S(`</script>
+<script>
+(function () {
+	"use strict";
+	var data = JSON.parse(document.getElementById("data").textContent);
+	var svgNS = "http://www.w3.org/2000/svg";
+	var nodes = data.nodes || [], edges = data.edges || [];
+	var byID = {}, byPath = {}, selected = null;
+	nodes.forEach(function (n) {
+		byID[n.id] = n;
+		byPath[n.path] = n;
+		n.out = [];
+		n.in = [];
+		n.cycle = false;
+	});
+	edges.forEach(function (e) {
+		byID[e.from].out.push(e);
+		byID[e.to].in.push(e);
+		if (e.cycle) {
+			byID[e.from].cycle = byID[e.to].cycle = true;
+		}
+	});
+
+	function forceLayout() {
+		var w = Math.max(800, Math.sqrt(nodes.length) * 160), h = w;
+		nodes.forEach(function (n, i) {
+			var a = 2 * Math.PI * i / nodes.length;
+			n.x = w / 2 + w / 3 * Math.cos(a);
+			n.y = h / 2 + h / 3 * Math.sin(a);
+		});
+		var k = Math.sqrt(w * h / Math.max(1, nodes.length)), t = w / 10;
+		for (var iter = 0; iter < 300; iter++) {
+			nodes.forEach(function (n) { n.dx = 0; n.dy = 0; });
+			for (var i = 0; i < nodes.length; i++) {
+				for (var j = i + 1; j < nodes.length; j++) {
+					var a = nodes[i], b = nodes[j];
+					var dx = a.x - b.x, dy = a.y - b.y, d = Math.max(0.01, Math.sqrt(dx * dx + dy * dy));
+					var f = k * k / d;
+					a.dx += dx / d * f; a.dy += dy / d * f;
+					b.dx -= dx / d * f; b.dy -= dy / d * f;
+				}
+			}
+			edges.forEach(function (e) {
+				var a = byID[e.from], b = byID[e.to];
+				var dx = a.x - b.x, dy = a.y - b.y, d = Math.max(0.01, Math.sqrt(dx * dx + dy * dy));
+				var f = d * d / k;
+				a.dx -= dx / d * f; a.dy -= dy / d * f;
+				b.dx += dx / d * f; b.dy += dy / d * f;
+			});
+			nodes.forEach(function (n) {
+				var d = Math.max(0.01, Math.sqrt(n.dx * n.dx + n.dy * n.dy));
+				n.x += n.dx / d * Math.min(d, t);
+				n.y += n.dy / d * Math.min(d, t);
+			});
+			t *= 0.98;
+		}
+	}
+
+	function hierarchicalLayout() {
+		// Layer of a node is the length of the longest import chain below it, cycles are cut
+		var layer = {}, visiting = {};
+		function depth(n) {
+			if (layer[n.id] !== undefined) {
+				return layer[n.id];
+			}
+			if (visiting[n.id]) {
+				return 0;
+			}
+			visiting[n.id] = true;
+			var l = 0;
+			n.out.forEach(function (e) { l = Math.max(l, depth(byID[e.to]) + 1); });
+			visiting[n.id] = false;
+			layer[n.id] = l;
+			return l;
+		}
+		var layers = [];
+		nodes.forEach(function (n) {
+			var l = depth(n);
+			(layers[l] = layers[l] || []).push(n);
+		});
+		layers.forEach(function (ns, l) {
+			ns.forEach(function (n, i) {
+				n.x = (layers.length - l) * 320;
+				n.y = i * 40;
+			});
+		});
+	}
+
+	function el(name, attrs, parent) {
+		var e = document.createElementNS(svgNS, name);
+		Object.keys(attrs).forEach(function (k) { e.setAttribute(k, attrs[k]); });
+		parent.appendChild(e);
+		return e;
+	}
+
+	function render() {
+		var onlyCycles = document.getElementById("cycles").checked;
+		var edgesG = document.getElementById("edges"), nodesG = document.getElementById("nodes");
+		edgesG.innerHTML = "";
+		nodesG.innerHTML = "";
+		edges.forEach(function (e) {
+			if (onlyCycles && !e.cycle) {
+				return;
+			}
+			var a = byID[e.from], b = byID[e.to];
+			// Width of the edges grows with the number of the referenced symbols
+			e.el = el("line", {x1: a.x, y1: a.y, x2: b.x, y2: b.y, "class": "edge" + (e.cycle ? " cycle" : ""),
+				"stroke-width": Math.min(6, 1 + Math.log(1 + (e.weight || 0))), "marker-end": "url(#arrow)"}, edgesG);
+			el("title", {}, e.el).textContent = a.path + " -> " + b.path + " (" + (e.weight || 0) + " references)";
+		});
+		nodes.forEach(function (n) {
+			n.el = null;
+			if (onlyCycles && !n.cycle) {
+				return;
+			}
+			var g = el("g", {"class": "node", transform: "translate(" + n.x + "," + n.y + ")"}, nodesG);
+			var w = Math.max(40, n.path.length * 5.6 + 10);
+			el("rect", {x: -w / 2, y: -9, width: w, height: 18, rx: 4, "class": n.kind + (n.errors ? " broken" : "")}, g);
+			el("text", {"text-anchor": "middle", y: 4}, g).textContent = n.path;
+			g.addEventListener("click", function (ev) {
+				ev.stopPropagation();
+				select(n);
+			});
+			n.el = g;
+		});
+		highlight();
+	}
+
+	function highlight() {
+		var query = document.getElementById("search").value.toLowerCase();
+		var related = null;
+		if (selected) {
+			related = {};
+			related[selected.id] = true;
+			selected.out.forEach(function (e) { related[e.to] = true; });
+			selected.in.forEach(function (e) { related[e.from] = true; });
+		}
+		nodes.forEach(function (n) {
+			if (!n.el) {
+				return;
+			}
+			var cls = "node";
+			if (n === selected) {
+				cls += " selected";
+			} else if (query && n.path.toLowerCase().indexOf(query) >= 0) {
+				cls += " matched";
+			}
+			if (related && !related[n.id]) {
+				cls += " dimmed";
+			}
+			n.el.setAttribute("class", cls);
+		});
+		edges.forEach(function (e) {
+			if (!e.el) {
+				return;
+			}
+			var cls = "edge" + (e.cycle ? " cycle" : "");
+			if (selected && e.from !== selected.id && e.to !== selected.id) {
+				cls += " dimmed";
+			}
+			e.el.setAttribute("class", cls);
+		});
+	}
+
+	function list(title, items, clickable) {
+		var html = "<h3>" + title + " (" + (items || []).length + ")</h3><ul>";
+		(items || []).forEach(function (i) {
+			var text = String(i).replace(/&/g, "&amp;").replace(/</g, "&lt;");
+			html += clickable && byPath[i] ? "<li class=\"link\" data-path=\"" + text + "\">" + text + "</li>" : "<li>" + text + "</li>";
+		});
+		return html + "</ul>";
+	}
+
+	function select(n) {
+		selected = n;
+		var details = document.getElementById("details");
+		if (!n) {
+			details.innerHTML = "<p>Click on a package to see its details.</p>";
+		} else {
+			details.innerHTML = "<h2>" + (n.name || n.path) + "</h2><p>" + n.path + " <i>(" + n.kind + ")</i>" +
+				(n.cycle ? " <b class=\"cycle\">in cycle</b>" : "") + "</p>" +
+				(n.errors ? list("Errors", n.errors) : "") +
+				list("Imports", n.imported || n.out.map(function (e) { return byID[e.to].path; }), true) +
+				list("Imported By", n.importedBy || n.in.map(function (e) { return byID[e.from].path; }), true) +
+				list("Exported Functions", n.funcs) +
+				list("Exported Types", n.types) +
+				list("Exported Fields", n.fields) +
+				list("Exported Methods", n.methods) +
+				list("Exported Constants", n.constants) +
+				list("Exported Variables", n.variables);
+			Array.prototype.forEach.call(details.querySelectorAll("li.link"), function (li) {
+				li.addEventListener("click", function () { select(byPath[li.getAttribute("data-path")]); });
+			});
+		}
+		highlight();
+	}
+
+	function search() {
+		var query = document.getElementById("search").value.toLowerCase();
+		var results = document.getElementById("results");
+		results.innerHTML = "";
+		if (query) {
+			nodes.filter(function (n) { return n.path.toLowerCase().indexOf(query) >= 0; }).forEach(function (n) {
+				var div = document.createElement("div");
+				div.textContent = n.path;
+				div.addEventListener("click", function () { select(n); });
+				results.appendChild(div);
+			});
+		}
+		highlight();
+	}
+
+	function layout() {
+		if (document.getElementById("layout").checked) {
+			hierarchicalLayout();
+		} else {
+			forceLayout();
+		}
+		render();
+	}
+
+	// Pan and zoom
+	var svg = document.getElementById("canvas"), viewport = document.getElementById("viewport");
+	var scale = 1, tx = 0, ty = 0, drag = null;
+	function transform() {
+		viewport.setAttribute("transform", "translate(" + tx + "," + ty + ") scale(" + scale + ")");
+	}
+	svg.addEventListener("wheel", function (ev) {
+		ev.preventDefault();
+		var f = ev.deltaY < 0 ? 1.1 : 1 / 1.1;
+		tx = ev.offsetX - (ev.offsetX - tx) * f;
+		ty = ev.offsetY - (ev.offsetY - ty) * f;
+		scale *= f;
+		transform();
+	});
+	svg.addEventListener("mousedown", function (ev) { drag = {x: ev.clientX - tx, y: ev.clientY - ty}; });
+	svg.addEventListener("mousemove", function (ev) {
+		if (drag) {
+			tx = ev.clientX - drag.x;
+			ty = ev.clientY - drag.y;
+			transform();
+		}
+	});
+	window.addEventListener("mouseup", function () { drag = null; });
+	svg.addEventListener("click", function () { select(null); });
+
+	var defs = el("defs", {}, svg);
+	var marker = el("marker", {id: "arrow", viewBox: "0 0 10 10", refX: 10, refY: 5, markerWidth: 6, markerHeight: 6,
+		orient: "auto-start-reverse"}, defs);
+	el("path", {d: "M 0 0 L 10 5 L 0 10 z", fill: "#7A869A"}, marker);
+
+	document.getElementById("search").addEventListener("input", search);
+	document.getElementById("layout").addEventListener("change", layout);
+	document.getElementById("cycles").addEventListener("change", render);
+	layout();
+})();
+</script>
+</body>
+</html>
+`)
+//line html.qtpl:312
+}
+
+//line html.qtpl:312
+func (r *htmlReport) WriteHTML(qq422016 qtio422016.Writer) {
+//line html.qtpl:312
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line html.qtpl:312
+	r.StreamHTML(qw422016)
+//line html.qtpl:312
+	qt422016.ReleaseWriter(qw422016)
+//line html.qtpl:312
+}
+
+//line html.qtpl:312
+func (r *htmlReport) HTML() string {
+//line html.qtpl:312
+	qb422016 := qt422016.AcquireByteBuffer()
+//line html.qtpl:312
+	r.WriteHTML(qb422016)
+//line html.qtpl:312
+	qs422016 := string(qb422016.B)
+//line html.qtpl:312
+	qt422016.ReleaseByteBuffer(qb422016)
+//line html.qtpl:312
+	return qs422016
+//line html.qtpl:312
+}
@@ -0,0 +1,209 @@
+package godeep
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+type DependentKind int
+
+const (
+	DependentPackage DependentKind = iota
+	DependentMain
+	// DependentTest is the tests of a package, both its internal _test.go files and its
+	// external _test package. The path of the dependent is the path of the package with
+	// the _test suffix.
+	DependentTest
+)
+
+func (k DependentKind) String() string {
+	switch k {
+	case DependentPackage:
+		return "package"
+	case DependentMain:
+		return "main"
+	case DependentTest:
+		return "test"
+	}
+	return "unknown"
+}
+
+type Dependent struct {
+	Path     string
+	Kind     DependentKind
+	Distance int
+}
+
+// Dependents returns the packages which import pkgPath. If transitive is set, it returns every
+// package, main package and test package which is affected by a change in pkgPath. The result
+// is sorted by the distance and then by the path.
+func (a *Packages) Dependents(pkgPath string, transitive bool) []Dependent {
+	a.mtx.RLock()
+	defer a.mtx.RUnlock()
+
+	distances := a.reverseGraph().distances(pkgPath, transitive)
+	dependents := make([]Dependent, 0, len(distances))
+	for p, d := range distances {
+		if p == pkgPath {
+			continue
+		}
+		dependents = append(dependents, Dependent{
+			Path:     p,
+			Kind:     a.dependentKind(p),
+			Distance: d,
+		})
+	}
+	sort.Slice(dependents, func(i, j int) bool {
+		if dependents[i].Distance != dependents[j].Distance {
+			return dependents[i].Distance < dependents[j].Distance
+		}
+		return dependents[i].Path < dependents[j].Path
+	})
+	return dependents
+}
+
+// Impact is the number of the packages, main packages and test packages which are affected by a
+// change in the package.
+type Impact struct {
+	Path  string
+	Count int
+}
+
+// Impacts ranks the packages by the number of their transitive dependents, the riskiest first.
+// Test packages are not ranked.
+func (a *Packages) Impacts() []Impact {
+	a.mtx.RLock()
+	defer a.mtx.RUnlock()
+
+	reverse := a.reverseGraph()
+	var impacts []Impact
+	for pkgPath, p := range a.byPath {
+		if p.forTest != "" {
+			continue
+		}
+		impacts = append(impacts, Impact{
+			Path:  pkgPath,
+			Count: len(reverse.distances(pkgPath, true)) - 1,
+		})
+	}
+	sort.Slice(impacts, func(i, j int) bool {
+		if impacts[i].Count != impacts[j].Count {
+			return impacts[i].Count > impacts[j].Count
+		}
+		return impacts[i].Path < impacts[j].Path
+	})
+	return impacts
+}
+
+// reverseGraph builds the graph of importedBy edges. The imports of the internal test files of
+// a package are attributed to the test node of the package (the path with the _test suffix),
+// which is shared with its external test package.
+func (a *Packages) reverseGraph() graph {
+	g := graph{}
+	for pkgPath, p := range a.byPath {
+		if _, ok := g[pkgPath]; !ok {
+			g[pkgPath] = nil
+		}
+		for _, to := range p.imported {
+			g.addEdge(to, pkgPath)
+		}
+		for _, to := range p.testImported {
+			g.addEdge(to, testPath(pkgPath))
+		}
+	}
+	return g
+}
+
+func (a *Packages) dependentKind(pkgPath string) DependentKind {
+	if strings.HasSuffix(pkgPath, "_test") {
+		return DependentTest
+	}
+	if p := a.byPath[pkgPath]; p != nil && p.name == "main" {
+		return DependentMain
+	}
+	return DependentPackage
+}
+
+func testPath(pkgPath string) string {
+	return pkgPath + "_test"
+}
+
+// Affected maps the changed files (absolute paths) to their packages and returns the sorted
+// paths of the packages whose tests must run, i.e. the packages which have tests and are
+// affected by the changes directly or through their dependencies. A changed go.mod or go.sum
+// affects the packages of its module, but not the packages of the modules nested in it.
+func (a *Packages) Affected(files []string) []string {
+	a.mtx.RLock()
+	defer a.mtx.RUnlock()
+	changed := map[string]bool{}
+	byDir := map[string][]*Package{}
+	for _, p := range a.byPath {
+		if p.dir != "" {
+			byDir[p.dir] = append(byDir[p.dir], p)
+		}
+	}
+	for _, f := range files {
+		dir := filepath.Dir(f)
+		base := filepath.Base(f)
+		switch {
+		case base == "go.mod" || base == "go.sum":
+			for d, pkgs := range byDir {
+				if (d == dir || strings.HasPrefix(d, dir+string(os.PathSeparator))) && moduleRoot(d) == dir {
+					for _, p := range pkgs {
+						changed[p.path] = true
+					}
+				}
+			}
+		case strings.HasSuffix(base, "_test.go"):
+			for _, p := range byDir[dir] {
+				changed[testPath(p.testedPath())] = true
+			}
+		case strings.HasSuffix(base, ".go"):
+			for _, p := range byDir[dir] {
+				if p.forTest == "" {
+					changed[p.path] = true
+				}
+			}
+		default:
+			// Other files (i.e. testdata or embedded files) belong to the nearest package
+			for ; byDir[dir] == nil && filepath.Dir(dir) != dir; dir = filepath.Dir(dir) {
+			}
+			for _, p := range byDir[dir] {
+				changed[p.path] = true
+			}
+		}
+	}
+
+	reverse := a.reverseGraph()
+	affected := map[string]bool{}
+	for pkgPath := range changed {
+		affected[pkgPath] = true
+		if strings.HasSuffix(pkgPath, "_test") {
+			continue
+		}
+		for p := range reverse.distances(pkgPath, true) {
+			affected[p] = true
+		}
+	}
+
+	var res []string
+	for pkgPath := range affected {
+		tested := strings.TrimSuffix(pkgPath, "_test")
+		if !a.hasTests(tested) || containsString(res, tested) {
+			continue
+		}
+		res = append(res, tested)
+	}
+	sort.Strings(res)
+	return res
+}
+
+func (a *Packages) hasTests(pkgPath string) bool {
+	if a.byPath[testPath(pkgPath)] != nil {
+		return true
+	}
+	p := a.byPath[pkgPath]
+	return p != nil && len(p.testFiles) > 0
+}
@@ -0,0 +1,94 @@
+package godeep
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// chainPackages returns a snapshot of n packages where every package imports the one before it,
+// so the first package has the most dependents.
+func chainPackages(tb testing.TB, n int) *Packages {
+	tb.Helper()
+	data := `{"version": 1, "packages": [`
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			data += ","
+		}
+		imported := ""
+		if i > 0 {
+			imported = fmt.Sprintf(`, "imported": ["example.com/p%04d"]`, i-1)
+		}
+		data += fmt.Sprintf(`{"name": "p", "path": "example.com/p%04d"%s}`, i, imported)
+	}
+	data += `]}`
+	a := InitPackages()
+	if err := a.Unmarshal([]byte(data)); err != nil {
+		tb.Fatal(err)
+	}
+	return a
+}
+
+func TestImpacts(t *testing.T) {
+	impacts := chainPackages(t, 4).Impacts()
+	expected := []Impact{
+		{Path: "example.com/p0000", Count: 3},
+		{Path: "example.com/p0001", Count: 2},
+		{Path: "example.com/p0002", Count: 1},
+		{Path: "example.com/p0003", Count: 0},
+	}
+	if len(impacts) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, impacts)
+	}
+	for idx := range expected {
+		if impacts[idx] != expected[idx] {
+			t.Errorf("expected %v, got %v", expected[idx], impacts[idx])
+		}
+	}
+}
+
+func TestAffected(t *testing.T) {
+	dir := writeModule(t, map[string]string{
+		"go.mod":           "module example.com/r\n\ngo 1.22\n",
+		"a/a.go":           "package a\n\nconst A = 1\n",
+		"a/a_test.go":      "package a\n",
+		"b/b.go":           "package b\n\nimport \"example.com/r/a\"\n\nconst B = a.A\n",
+		"b/b_test.go":      "package b_test\n",
+		"c/c.go":           "package c\n\nimport \"example.com/r/a\"\n\nconst C = a.A\n",
+		"d/d.go":           "package d\n",
+		"d/d_test.go":      "package d\n",
+		"d/testdata/x.txt": "x\n",
+		"sub/go.mod":       "module example.com/r/sub\n\ngo 1.22\n",
+		"sub/s.go":         "package sub\n",
+		"sub/s_test.go":    "package sub\n",
+	})
+	a := analyze(t, dir)
+	tests := []struct {
+		file     string
+		affected []string
+	}{
+		// c is affected too, but it has no tests
+		{"a/a.go", []string{"example.com/r/a", "example.com/r/b"}},
+		{"a/a_test.go", []string{"example.com/r/a"}},
+		{"b/b_test.go", []string{"example.com/r/b"}},
+		{"d/testdata/x.txt", []string{"example.com/r/d"}},
+		{"go.mod", []string{"example.com/r/a", "example.com/r/b", "example.com/r/d"}},
+		{"sub/go.sum", []string{"example.com/r/sub"}},
+		{"README.md", nil},
+	}
+	for _, tt := range tests {
+		affected := a.Affected([]string{filepath.Join(dir, filepath.FromSlash(tt.file))})
+		if !reflect.DeepEqual(affected, tt.affected) {
+			t.Errorf("%s: expected %v, got %v", tt.file, tt.affected, affected)
+		}
+	}
+}
+
+func BenchmarkImpacts(b *testing.B) {
+	a := chainPackages(b, 500)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		a.Impacts()
+	}
+}
@@ -0,0 +1,257 @@
+package godeep
+
+import (
+	"fmt"
+	"go/types"
+	"sort"
+)
+
+// Implementation is a concrete type of the analyzed packages which satisfies an interface. Types
+// and interfaces are qualified by their package path, i.e. 'example.com/a/b.Client', and Pointer
+// is set if only the pointer of the type satisfies the interface.
+type Implementation struct {
+	Type      string
+	Interface string
+	Pointer   bool
+}
+
+// namedType is a concrete type of the analyzed packages.
+type namedType struct {
+	name string
+	typ  *types.Named
+}
+
+// namedInterface is an exported interface of the analyzed packages or their dependencies. The
+// modules which are not in a go.work workspace are loaded separately and the types of each load
+// have their own identities, so a type is checked against the interface of its own load.
+type namedInterface struct {
+	name    string
+	pkgPath string
+	objName string
+	iface   *types.Interface
+	loads   map[*types.Package]*types.Interface
+}
+
+// Implements returns the concrete types of the analyzed packages which satisfy the interface, the
+// interface could be declared by any analyzed package or their dependencies.
+func (a *Packages) Implements(iface string) ([]Implementation, error) {
+	a.mtx.RLock()
+	defer a.mtx.RUnlock()
+	if err := a.checkTypes(); err != nil {
+		return nil, err
+	}
+	i, err := a.lookupInterface(iface)
+	if err != nil {
+		return nil, err
+	}
+	var res []Implementation
+	for _, t := range a.namedTypes() {
+		if impl, ok := implements(t, i); ok {
+			res = append(res, impl)
+		}
+	}
+	return res, nil
+}
+
+// ImplementedBy returns the interfaces of the analyzed packages and their dependencies which are
+// satisfied by the type.
+func (a *Packages) ImplementedBy(typ string) ([]Implementation, error) {
+	a.mtx.RLock()
+	defer a.mtx.RUnlock()
+	if err := a.checkTypes(); err != nil {
+		return nil, err
+	}
+	var t *namedType
+	for _, nt := range a.namedTypes() {
+		if nt.name == typ {
+			t = &nt
+			break
+		}
+	}
+	if t == nil {
+		return nil, fmt.Errorf("type '%s' not found in the analyzed packages", typ)
+	}
+	var res []Implementation
+	for _, i := range a.namedInterfaces() {
+		if impl, ok := implements(*t, i); ok {
+			res = append(res, impl)
+		}
+	}
+	return res, nil
+}
+
+// HasTypes returns true if the type information of all the packages is available, the packages
+// imported from a snapshot or restored from the cache have no types.
+func (a *Packages) HasTypes() bool {
+	a.mtx.RLock()
+	defer a.mtx.RUnlock()
+	return a.hasTypes()
+}
+
+func (a *Packages) hasTypes() bool {
+	for _, p := range a.byPath {
+		// The packages which could not be loaded have no types anyway
+		if p.typesPkg == nil && len(p.errors) == 0 {
+			return false
+		}
+	}
+	return len(a.byPath) > 0
+}
+
+// checkTypes returns an error if any package has no types.
+func (a *Packages) checkTypes() error {
+	if a.hasTypes() {
+		return nil
+	}
+	return fmt.Errorf("type information is not available, the packages must be analyzed without the cache")
+}
+
+func implements(t namedType, i namedInterface) (Implementation, bool) {
+	impl := Implementation{Type: t.name, Interface: i.name}
+	iface := i.in(t.typ.Obj().Pkg())
+	if types.Implements(t.typ, iface) {
+		return impl, true
+	}
+	impl.Pointer = true
+	return impl, types.Implements(types.NewPointer(t.typ), iface)
+}
+
+// in returns the interface as it is seen by the package, if the package does not import the
+// package of the interface, directly or indirectly, the interface could still be satisfied by
+// methods which only use the predeclared types.
+func (i namedInterface) in(pkg *types.Package) *types.Interface {
+	if i.pkgPath == "" || pkg == nil {
+		return i.iface
+	}
+	if iface, ok := i.loads[pkg]; ok {
+		return iface
+	}
+	iface := i.iface
+	visited := map[*types.Package]bool{}
+	queue := []*types.Package{pkg}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		if p.Path() == i.pkgPath {
+			if o, ok := p.Scope().Lookup(i.objName).(*types.TypeName); ok {
+				if x, ok := o.Type().Underlying().(*types.Interface); ok {
+					iface = x
+				}
+			}
+			break
+		}
+		for _, imported := range p.Imports() {
+			if !visited[imported] {
+				visited[imported] = true
+				queue = append(queue, imported)
+			}
+		}
+	}
+	i.loads[pkg] = iface
+	return iface
+}
+
+// namedTypes returns the concrete named types of the analyzed packages, the generic types are
+// skipped since their methods depend on the type arguments.
+func (a *Packages) namedTypes() []namedType {
+	var res []namedType
+	for _, pkgPath := range keys(a.byPath) {
+		pkg := a.byPath[pkgPath].typesPkg
+		if pkg == nil {
+			continue
+		}
+		for _, name := range pkg.Scope().Names() {
+			o, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+			if !ok || o.IsAlias() {
+				continue
+			}
+			named, ok := o.Type().(*types.Named)
+			if !ok || named.TypeParams().Len() > 0 || types.IsInterface(named) {
+				continue
+			}
+			res = append(res, namedType{
+				name: objectPath(o),
+				typ:  named,
+			})
+		}
+	}
+	return res
+}
+
+// namedInterfaces returns the exported interfaces which have methods, declared by the analyzed
+// packages and all their dependencies. The constraints which are not method sets are skipped.
+func (a *Packages) namedInterfaces() []namedInterface {
+	var res []namedInterface
+	visited := map[string]bool{}
+	var visit func(pkg *types.Package)
+	visit = func(pkg *types.Package) {
+		if visited[pkg.Path()] {
+			return
+		}
+		visited[pkg.Path()] = true
+		for _, name := range pkg.Scope().Names() {
+			if i, ok := newNamedInterface(pkg.Scope().Lookup(name)); ok {
+				res = append(res, i)
+			}
+		}
+		for _, imported := range pkg.Imports() {
+			visit(imported)
+		}
+	}
+	for _, pkgPath := range keys(a.byPath) {
+		if pkg := a.byPath[pkgPath].typesPkg; pkg != nil {
+			visit(pkg)
+		}
+	}
+	if i, ok := newNamedInterface(types.Universe.Lookup("error")); ok {
+		res = append(res, i)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].name < res[j].name
+	})
+	return res
+}
+
+// lookupInterface finds the interface by its name qualified by the package path, i.e. 'io.Reader'.
+func (a *Packages) lookupInterface(name string) (namedInterface, error) {
+	for _, i := range a.namedInterfaces() {
+		if i.name == name {
+			return i, nil
+		}
+	}
+	return namedInterface{}, fmt.Errorf("interface '%s' not found in the analyzed packages or their dependencies", name)
+}
+
+func newNamedInterface(o types.Object) (namedInterface, bool) {
+	tn, ok := o.(*types.TypeName)
+	// The error interface is the only unexported interface which is not in any package
+	if !ok || tn.IsAlias() || (tn.Pkg() != nil && !tn.Exported()) {
+		return namedInterface{}, false
+	}
+	named, ok := tn.Type().(*types.Named)
+	if !ok || named.TypeParams().Len() > 0 {
+		return namedInterface{}, false
+	}
+	iface, ok := named.Underlying().(*types.Interface)
+	if !ok || !iface.IsMethodSet() || iface.NumMethods() == 0 {
+		return namedInterface{}, false
+	}
+	i := namedInterface{
+		name:    objectPath(tn),
+		objName: tn.Name(),
+		iface:   iface,
+		loads:   map[*types.Package]*types.Interface{},
+	}
+	if tn.Pkg() != nil {
+		i.pkgPath = tn.Pkg().Path()
+	}
+	return i, true
+}
+
+// objectPath returns the name of the object qualified by its package path.
+func objectPath(o types.Object) string {
+	if o.Pkg() == nil {
+		return o.Name()
+	}
+	return fmt.Sprintf("%s.%s", o.Pkg().Path(), o.Name())
+}
@@ -0,0 +1,53 @@
+package godeep
+
+import (
+	"testing"
+)
+
+func TestImplementsIgnoresParameterNames(t *testing.T) {
+	dir := writeModule(t, map[string]string{
+		"go.mod": "module example.com/s\n\ngo 1.22\n",
+		"a/a.go": `package a
+
+type Doer interface {
+	Do(ch <-chan int) error
+}
+`,
+		"c/c.go": `package c
+
+type Impl struct{}
+
+func (Impl) Do(other <-chan int) error { return nil }
+
+type PtrImpl struct{}
+
+func (*PtrImpl) Do(_ <-chan int) error { return nil }
+`,
+	})
+	a := analyze(t, dir)
+
+	impls, err := a.Implements("example.com/s/a.Doer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Implementation{
+		{Type: "example.com/s/c.Impl", Interface: "example.com/s/a.Doer"},
+		{Type: "example.com/s/c.PtrImpl", Interface: "example.com/s/a.Doer", Pointer: true},
+	}
+	if len(impls) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, impls)
+	}
+	for idx := range expected {
+		if impls[idx] != expected[idx] {
+			t.Errorf("expected %v, got %v", expected[idx], impls[idx])
+		}
+	}
+
+	impls, err = a.ImplementedBy("example.com/s/c.Impl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(impls) != 1 || impls[0].Interface != "example.com/s/a.Doer" {
+		t.Errorf("expected example.com/s/a.Doer, got %v", impls)
+	}
+}
@@ -0,0 +1,243 @@
+// Code generated by qtc from "mermaid.qtpl". DO NOT EDIT.
+// See https://github.com/valyala/quicktemplate for details.
+
+// Mermaid flowchart rendering of the import graph
+
+//line mermaid.qtpl:3
+package godeep
+
+//line mermaid.qtpl:3
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line mermaid.qtpl:3
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+//line mermaid.qtpl:3
+func (d *diagram) StreamMermaid(qw422016 *qt422016.Writer) {
+//line mermaid.qtpl:3
+	qw422016.N().S(`graph LR`)
+//line mermaid.qtpl:4
+	qw422016.N().S(`
+`)
+//line mermaid.qtpl:5
+	for i, c := range d.Clusters {
+//line mermaid.qtpl:6
+		if c.Name != "" {
+//line mermaid.qtpl:6
+			qw422016.N().S(`subgraph c`)
+//line mermaid.qtpl:7
+			qw422016.N().D(i)
+//line mermaid.qtpl:7
+			qw422016.N().S(`["`)
+//line mermaid.qtpl:7
+			qw422016.E().S(c.Name)
+//line mermaid.qtpl:7
+			qw422016.N().S(`"]`)
+//line mermaid.qtpl:7
+			qw422016.N().S(`
+`)
+//line mermaid.qtpl:8
+		}
+//line mermaid.qtpl:9
+		for _, n := range c.Nodes {
+//line mermaid.qtpl:10
+			qw422016.E().S(d.id(n.Path))
+//line mermaid.qtpl:10
+			qw422016.N().S(`["`)
+//line mermaid.qtpl:10
+			qw422016.E().S(n.Path)
+//line mermaid.qtpl:10
+			qw422016.N().S(`"]:::`)
+//line mermaid.qtpl:10
+			streammermaidClass(qw422016, n.Kind)
+//line mermaid.qtpl:10
+			qw422016.N().S(`
+`)
+//line mermaid.qtpl:11
+		}
+//line mermaid.qtpl:12
+		if c.Name != "" {
+//line mermaid.qtpl:12
+			qw422016.N().S(`end`)
+//line mermaid.qtpl:13
+			qw422016.N().S(`
+`)
+//line mermaid.qtpl:14
+		}
+//line mermaid.qtpl:15
+	}
+//line mermaid.qtpl:16
+	for _, e := range d.Edges {
+//line mermaid.qtpl:17
+		qw422016.E().S(d.id(e.From))
+//line mermaid.qtpl:17
+		qw422016.N().S(` `)
+//line mermaid.qtpl:17
+		qw422016.N().S(`-->`)
+//line mermaid.qtpl:18
+		if e.Weight > 0 {
+//line mermaid.qtpl:18
+			qw422016.N().S(`|`)
+//line mermaid.qtpl:19
+			qw422016.N().D(e.Weight)
+//line mermaid.qtpl:19
+			qw422016.N().S(`|`)
+//line mermaid.qtpl:20
+		}
+//line mermaid.qtpl:21
+		qw422016.N().S(` `)
+//line mermaid.qtpl:21
+		qw422016.E().S(d.id(e.To))
+//line mermaid.qtpl:21
+		qw422016.N().S(`
+`)
+//line mermaid.qtpl:22
+	}
+//line mermaid.qtpl:23
+	for _, c := range d.Clusters {
+//line mermaid.qtpl:24
+		for _, n := range c.Nodes {
+//line mermaid.qtpl:25
+			if n.Broken {
+//line mermaid.qtpl:25
+				qw422016.N().S(`class`)
+//line mermaid.qtpl:26
+				qw422016.N().S(` `)
+//line mermaid.qtpl:26
+				qw422016.E().S(d.id(n.Path))
+//line mermaid.qtpl:26
+				qw422016.N().S(` `)
+//line mermaid.qtpl:26
+				qw422016.N().S(`broken`)
+//line mermaid.qtpl:26
+				qw422016.N().S(`
+`)
+//line mermaid.qtpl:27
+			}
+//line mermaid.qtpl:28
+		}
+//line mermaid.qtpl:29
+	}
+//line mermaid.qtpl:30
+	for i, e := range d.Edges {
+//line mermaid.qtpl:31
+		if e.Cycle {
+//line mermaid.qtpl:31
+			qw422016.N().S(`linkStyle`)
+//line mermaid.qtpl:32
+			qw422016.N().S(` `)
+//line mermaid.qtpl:32
+			qw422016.N().D(i)
+//line mermaid.qtpl:32
+			qw422016.N().S(` `)
+//line mermaid.qtpl:32
+			qw422016.N().S(`stroke:#DE350B,stroke-width:2px`)
+//line mermaid.qtpl:32
+			qw422016.N().S(`
+`)
+//line mermaid.qtpl:33
+		}
+//line mermaid.qtpl:34
+	}
+//line mermaid.qtpl:34
+	qw422016.N().S(`classDef internal fill:#B3D4FF`)
+//line mermaid.qtpl:35
+	qw422016.N().S(`
+`)
+//line mermaid.qtpl:35
+	qw422016.N().S(`classDef thirdParty fill:#FFE380`)
+//line mermaid.qtpl:36
+	qw422016.N().S(`
+`)
+//line mermaid.qtpl:36
+	qw422016.N().S(`classDef std fill:#EBECF0`)
+//line mermaid.qtpl:37
+	qw422016.N().S(`
+`)
+//line mermaid.qtpl:37
+	qw422016.N().S(`classDef broken stroke:#DE350B,stroke-width:2px,stroke-dasharray:4`)
+//line mermaid.qtpl:38
+	qw422016.N().S(`
+`)
+//line mermaid.qtpl:39
+}
+
+//line mermaid.qtpl:39
+func (d *diagram) WriteMermaid(qq422016 qtio422016.Writer) {
+//line mermaid.qtpl:39
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line mermaid.qtpl:39
+	d.StreamMermaid(qw422016)
+//line mermaid.qtpl:39
+	qt422016.ReleaseWriter(qw422016)
+//line mermaid.qtpl:39
+}
+
+//line mermaid.qtpl:39
+func (d *diagram) Mermaid() string {
+//line mermaid.qtpl:39
+	qb422016 := qt422016.AcquireByteBuffer()
+//line mermaid.qtpl:39
+	d.WriteMermaid(qb422016)
+//line mermaid.qtpl:39
+	qs422016 := string(qb422016.B)
+//line mermaid.qtpl:39
+	qt422016.ReleaseByteBuffer(qb422016)
+//line mermaid.qtpl:39
+	return qs422016
+//line mermaid.qtpl:39
+}
+
+//line mermaid.qtpl:41
+func streammermaidClass(qw422016 *qt422016.Writer, k NodeKind) {
+//line mermaid.qtpl:42
+	switch k {
+//line mermaid.qtpl:43
+	case NodeInternal:
+//line mermaid.qtpl:43
+		qw422016.N().S(`internal`)
+//line mermaid.qtpl:45
+	case NodeThirdParty:
+//line mermaid.qtpl:45
+		qw422016.N().S(`thirdParty`)
+//line mermaid.qtpl:47
+	case NodeStd:
+//line mermaid.qtpl:47
+		qw422016.N().S(`std`)
+//line mermaid.qtpl:49
+	}
+//line mermaid.qtpl:50
+}
+
+//line mermaid.qtpl:50
+func writemermaidClass(qq422016 qtio422016.Writer, k NodeKind) {
+//line mermaid.qtpl:50
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line mermaid.qtpl:50
+	streammermaidClass(qw422016, k)
+//line mermaid.qtpl:50
+	qt422016.ReleaseWriter(qw422016)
+//line mermaid.qtpl:50
+}
+
+//line mermaid.qtpl:50
+func mermaidClass(k NodeKind) string {
+//line mermaid.qtpl:50
+	qb422016 := qt422016.AcquireByteBuffer()
+//line mermaid.qtpl:50
+	writemermaidClass(qb422016, k)
+//line mermaid.qtpl:50
+	qs422016 := string(qb422016.B)
+//line mermaid.qtpl:50
+	qt422016.ReleaseByteBuffer(qb422016)
+//line mermaid.qtpl:50
+	return qs422016
+//line mermaid.qtpl:50
+}
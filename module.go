@@ -0,0 +1,308 @@
+package godeep
+
+import (
+	"github.com/fatih/color"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/tools/go/packages"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Module is a module of the module graph. The main modules are the analyzed ones, their Dir is
+// set and they have no Version. Replace is the replacement of the module, i.e. '../lib' or
+// 'example.com/fork@v1.0.0'. Requires are the versions of the required modules declared in the
+// go.mod of the main modules, and Imports are the modules whose packages are imported by the
+// packages of the module, including the test files, with the number of the package edges behind
+// each of them.
+type Module struct {
+	Path     string            `json:"path"`
+	Version  string            `json:"version,omitempty"`
+	Replace  string            `json:"replace,omitempty"`
+	Dir      string            `json:"dir,omitempty"`
+	Main     bool              `json:"main,omitempty"`
+	Requires map[string]string `json:"requires,omitempty"`
+	Imports  map[string]int    `json:"imports,omitempty"`
+}
+
+// ModuleEdge is an edge of the module graph, Weight is the number of the package edges behind it.
+type ModuleEdge struct {
+	From   string
+	To     string
+	Weight int
+}
+
+func (m Module) String() string {
+	s := m.Path
+	if m.Version != "" {
+		s += "@" + m.Version
+	}
+	if m.Replace != "" {
+		s += " => " + m.Replace
+	}
+	return s
+}
+
+// Modules returns the modules of the analyzed packages and the modules they import, sorted by path.
+func (a *Packages) Modules() []Module {
+	a.mtx.RLock()
+	defer a.mtx.RUnlock()
+	res := make([]Module, 0, len(a.modules))
+	for _, m := range a.modules {
+		res = append(res, *m)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Path < res[j].Path
+	})
+	return res
+}
+
+// ModuleEdges returns the edges of the module graph sorted by their modules.
+func (a *Packages) ModuleEdges() []ModuleEdge {
+	a.mtx.RLock()
+	defer a.mtx.RUnlock()
+	return a.moduleEdges()
+}
+
+func (a *Packages) moduleEdges() []ModuleEdge {
+	var res []ModuleEdge
+	for from, m := range a.modules {
+		for to, weight := range m.Imports {
+			res = append(res, ModuleEdge{From: from, To: to, Weight: weight})
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].From != res[j].From {
+			return res[i].From < res[j].From
+		}
+		return res[i].To < res[j].To
+	})
+	return res
+}
+
+// mainModuleGraph returns the graph of the main modules, the other modules could not import
+// the main modules back, unless they are required by a version which is not analyzed.
+func (a *Packages) mainModuleGraph() graph {
+	g := graph{}
+	for from, m := range a.modules {
+		if !m.Main {
+			continue
+		}
+		if _, ok := g[from]; !ok {
+			g[from] = nil
+		}
+		for to := range m.Imports {
+			if a.modules[to] != nil && a.modules[to].Main {
+				g.addEdge(from, to)
+			}
+		}
+	}
+	return g
+}
+
+// GetModule returns the module by its path.
+func (a *Packages) GetModule(path string) *Module {
+	a.mtx.RLock()
+	defer a.mtx.RUnlock()
+	return a.modules[path]
+}
+
+// PrintModules prints the modules with the modules they import and the cycles between the main
+// modules, the weights are the number of the package edges behind every module edge.
+func (a *Packages) PrintModules() {
+	edges := a.ModuleEdges()
+	for _, m := range a.Modules() {
+		if m.Main {
+			color.Green("========== %s (main: %s) ========", m, m.Dir)
+		} else {
+			color.Green("========== %s ========", m)
+		}
+		var imports, importedBy []ModuleEdge
+		for _, e := range edges {
+			switch m.Path {
+			case e.From:
+				imports = append(imports, e)
+			case e.To:
+				importedBy = append(importedBy, e)
+			}
+		}
+		color.Red("Imports: (%d)", len(imports))
+		for idx, e := range imports {
+			color.Red("\t %d. %s x%d", idx+1, e.To, e.Weight)
+		}
+		color.HiBlue("imported By: (%d)", len(importedBy))
+		for idx, e := range importedBy {
+			color.HiBlue("\t %d. %s x%d", idx+1, e.From, e.Weight)
+		}
+	}
+	var cycles []Cycle
+	for _, c := range a.Cycles() {
+		if c.Kind == CycleModule {
+			cycles = append(cycles, c)
+		}
+	}
+	color.HiMagenta("Cycles (module): (%d)", len(cycles))
+	for idx, c := range cycles {
+		color.HiMagenta("\t %d. %s", idx+1, strings.Join(c.Path, " -> "))
+	}
+}
+
+// newModule returns the module of the loaded packages, the requirements are only read for the
+// main modules.
+func newModule(m *packages.Module) *Module {
+	res := &Module{
+		Path:    m.Path,
+		Version: m.Version,
+		Main:    m.Main,
+	}
+	if m.Replace != nil {
+		res.Replace = m.Replace.Path
+		if m.Replace.Version != "" {
+			res.Replace += "@" + m.Replace.Version
+		}
+	}
+	if m.Main {
+		res.Dir = m.Dir
+		res.Requires = readRequires(m.GoMod)
+	}
+	return res
+}
+
+// readRequires returns the versions of the modules required by the go.mod file.
+func readRequires(goModPath string) map[string]string {
+	data, err := os.ReadFile(goModPath)
+	if err != nil {
+		return nil
+	}
+	f, err := modfile.ParseLax(goModPath, data, nil)
+	if err != nil {
+		return nil
+	}
+	res := make(map[string]string, len(f.Require))
+	for _, r := range f.Require {
+		res[r.Mod.Path] = r.Mod.Version
+	}
+	return res
+}
+
+// addModules records the modules of the loaded packages and all their dependencies. A module
+// which is a dependency of a load could be the main module of another one, i.e. a nested module
+// which is replaced by a directory.
+func (a *Packages) addModules(pkgs []*packages.Package) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		if pkg.Module == nil {
+			return
+		}
+		m := a.modules[pkg.Module.Path]
+		switch {
+		case m == nil:
+			m = newModule(pkg.Module)
+			a.modules[m.Path] = m
+		case pkg.Module.Main && !m.Main:
+			*m = *newModule(pkg.Module)
+		}
+		a.packageModules[pkg.PkgPath] = m.Path
+	})
+}
+
+// buildModules builds the module graph from the imports of the analyzed packages. Only the main
+// modules, the modules of the analyzed packages and the modules they import are kept.
+func (a *Packages) buildModules() {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	imports := map[string]map[string]int{}
+	imported := map[string]bool{}
+	for _, pkgPath := range keys(a.byPath) {
+		p := a.byPath[pkgPath]
+		if p.module == "" {
+			// The packages outside of a module, i.e. GOPATH mode
+			continue
+		}
+		if imports[p.module] == nil {
+			imports[p.module] = map[string]int{}
+		}
+		for _, importPath := range append(append([]string{}, p.imported...), p.testImported...) {
+			to := a.packageModules[importPath]
+			if to == "" || to == p.module {
+				continue
+			}
+			imports[p.module][to]++
+			imported[to] = true
+		}
+	}
+	for path, m := range a.modules {
+		if _, analyzed := imports[path]; !analyzed && !m.Main && !imported[path] {
+			delete(a.modules, path)
+			continue
+		}
+		m.Imports = imports[path]
+		if len(m.Imports) == 0 {
+			m.Imports = nil
+		}
+	}
+}
+
+// hasPathPrefix returns true if the slash separated path is the prefix or is inside of it, i.e.
+// 'a/b' is inside 'a' but 'a/bc' is not inside 'a/b'.
+func hasPathPrefix(path, prefix string) bool {
+	return path == prefix || strings.HasPrefix(path, strings.TrimSuffix(prefix, "/")+"/")
+}
+
+// findWorkspace returns the path of the go.work file which the go command uses in the dir, it
+// respects the GOWORK environment variable.
+func findWorkspace(dir string) string {
+	switch gowork := os.Getenv("GOWORK"); gowork {
+	case "off":
+		return ""
+	case "":
+	default:
+		return gowork
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.work")); err == nil {
+			return filepath.Join(dir, "go.work")
+		}
+		if filepath.Dir(dir) == dir {
+			return ""
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+// workspaceModules returns the directories of the modules used by the go.work file, sorted.
+func workspaceModules(goWorkPath string) []string {
+	data, err := os.ReadFile(goWorkPath)
+	if err != nil {
+		return nil
+	}
+	f, err := modfile.ParseWork(goWorkPath, data, nil)
+	if err != nil {
+		return nil
+	}
+	var res []string
+	for _, u := range f.Use {
+		dir := u.Path
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(filepath.Dir(goWorkPath), dir)
+		}
+		res = append(res, filepath.Clean(dir))
+	}
+	sort.Strings(res)
+	return res
+}
+
+// moduleRoot returns the nearest directory of dir or its parents which has a go.mod file.
+func moduleRoot(dir string) string {
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir
+		}
+		if filepath.Dir(dir) == dir {
+			return ""
+		}
+		dir = filepath.Dir(dir)
+	}
+}
@@ -0,0 +1,93 @@
+package godeep
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestModulesOfWorkspace(t *testing.T) {
+	// The workspaces only accept -mod=readonly
+	t.Setenv("GOFLAGS", "")
+	dir := writeModule(t, map[string]string{
+		"go.work": "go 1.22\n\nuse (\n\t./app // the service\n\t\"./lib\"\n)\n",
+		"app/go.mod": `module example.com/app // https://example.com/app
+
+go 1.22
+
+require example.com/lib v0.1.0 // the workspace module
+`,
+		"app/main.go": `package main
+
+import "example.com/lib/log"
+
+func main() { log.Print() }
+`,
+		"lib/go.mod":     "module example.com/lib\n\ngo 1.22\n",
+		"lib/log/log.go": "package log\n\nfunc Print() {}\n",
+		// Not used by the workspace
+		"tools/go.mod":  "module example.com/tools\n\ngo 1.22\n",
+		"tools/tool.go": "package tools\n",
+	})
+	a := analyze(t, dir)
+	if a.GetByPath("example.com/tools") != nil {
+		t.Error("expected the module which is not used by the workspace to be ignored")
+	}
+	if p := a.GetByPath("example.com/lib/log"); p == nil || p.Module() != "example.com/lib" {
+		t.Fatalf("expected example.com/lib/log in example.com/lib, got %v", p)
+	}
+	app := a.GetModule("example.com/app")
+	if app == nil || !app.Main || !reflect.DeepEqual(app.Imports, map[string]int{"example.com/lib": 1}) {
+		t.Fatalf("expected example.com/app to import example.com/lib, got %+v", app)
+	}
+	if app.Requires["example.com/lib"] != "v0.1.0" {
+		t.Errorf("expected the required version without the comment, got %v", app.Requires)
+	}
+	if lib := a.GetModule("example.com/lib"); lib == nil || !lib.Main {
+		t.Errorf("expected example.com/lib to be a main module, got %+v", lib)
+	}
+}
+
+func TestModulesOfNestedModules(t *testing.T) {
+	dir := writeModule(t, map[string]string{
+		"go.mod": `module example.com/root
+
+go 1.22
+
+require example.com/lib v1.2.0
+
+replace example.com/lib => ./lib // develop locally
+`,
+		"root.go": `package root
+
+import "example.com/lib"
+
+var _ = lib.Name
+`,
+		"lib/go.mod": "module example.com/lib\n\ngo 1.22\n",
+		"lib/lib.go": "package lib\n\nconst Name = \"lib\"\n",
+		// The directory name does not tell the version of the module
+		"tools@v2.0.0/go.mod":   "module example.com/tools\n\ngo 1.22\n",
+		"tools@v2.0.0/tools.go": "package tools\n",
+	})
+	a := analyze(t, dir)
+	for pkgPath, module := range map[string]string{
+		"example.com/root":  "example.com/root",
+		"example.com/lib":   "example.com/lib",
+		"example.com/tools": "example.com/tools",
+	} {
+		p := a.GetByPath(pkgPath)
+		if p == nil || p.Module() != module || p.ModuleVersion() != "" {
+			t.Errorf("expected %s in %s without a version, got %v", pkgPath, module, p)
+		}
+	}
+	root := a.GetModule("example.com/root")
+	if root == nil || !reflect.DeepEqual(root.Imports, map[string]int{"example.com/lib": 1}) {
+		t.Fatalf("expected example.com/root to import example.com/lib, got %+v", root)
+	}
+	if lib := a.GetModule("example.com/lib"); lib == nil || !lib.Main {
+		t.Errorf("expected the nested example.com/lib to be a main module, got %+v", lib)
+	}
+	if tools := a.GetModule("example.com/tools"); tools == nil || tools.Version != "" {
+		t.Errorf("expected example.com/tools without a version, got %+v", tools)
+	}
+}
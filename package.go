@@ -1,12 +1,16 @@
 package godeep
 
 import (
-	"encoding/json"
 	"github.com/fatih/color"
-	"go/ast"
+	"go/token"
+	"go/types"
 	"golang.org/x/tools/go/packages"
 	"os"
+	"path"
 	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 )
@@ -23,66 +27,289 @@ import (
 //go:generate go get -u github.com/valyala/quicktemplate/qtc
 //go:generate qtc -dir=.
 
-func FindPackages(allPackages *Packages, rootPath string, onDone func(path string)) error {
+// FindPackages loads the packages of every module under the rootPath and fills the allPackages.
+// Each module is loaded by one call of packages.Load over the patterns, './...' by default, so
+// the shared dependencies are type-checked only once per module. If the rootPath is in a go.work
+// workspace, its modules are loaded together in a single pass and the other modules are ignored.
+// If the cache is used, only the changed packages and their dependents are loaded.
+func FindPackages(allPackages *Packages, rootPath string, onDone func(path string), patterns ...string) error {
 	allPackages.Reset()
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+	var (
+		moduleDirs []string
+		loads      []moduleLoad
+	)
+	if goWork := findWorkspace(rootPath); goWork != "" {
+		var workspacePatterns []string
+		for _, dir := range workspaceModules(goWork) {
+			rel, err := filepath.Rel(rootPath, dir)
+			switch {
+			case err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)):
+				workspacePatterns = append(workspacePatterns, modulePatterns(filepath.ToSlash(rel), patterns)...)
+			case hasPathPrefix(filepath.ToSlash(rootPath), filepath.ToSlash(dir)):
+				// The rootPath is inside of this module
+				workspacePatterns = append(workspacePatterns, patterns...)
+			default:
+				continue
+			}
+			moduleDirs = append(moduleDirs, dir)
+		}
+		if len(workspacePatterns) > 0 {
+			loads = append(loads, moduleLoad{dir: rootPath, patterns: workspacePatterns})
+		}
+	} else {
+		roots, err := findModuleRoots(rootPath)
+		if err != nil {
+			return err
+		}
+		for _, root := range roots {
+			if dir := moduleRoot(root); dir != "" {
+				moduleDirs = append(moduleDirs, dir)
+			}
+			loads = append(loads, moduleLoad{dir: root, patterns: patterns})
+		}
+	}
+
+	var cache *analysisCache
+	if allPackages.cacheDir != "" {
+		cache = openCache(allPackages.cacheDir, rootPath, patterns, moduleDirs)
+	}
+	for _, l := range loads {
+		if err := allPackages.load(l.dir, l.patterns, onDone, cache); err != nil {
+			return err
+		}
+	}
+	for path, pkg := range allPackages.byPath {
+		for iPath := range allPackages.importedBy[path] {
+			pkg.importedByPackages = append(pkg.importedByPackages, iPath)
+		}
+		sort.Strings(pkg.importedByPackages)
+	}
+	allPackages.buildModules()
+	if cache != nil {
+		// The cache is best effort, the analysis is done even if it could not be written
+		_ = cache.save(allPackages)
+	}
+	return nil
+}
+
+// loadMode is the information which Fill needs from the loaded packages and their dependencies.
+const loadMode = packages.NeedImports | packages.NeedDeps | packages.NeedTypes | packages.NeedName |
+	packages.NeedSyntax | packages.NeedFiles | packages.NeedTypesInfo | packages.NeedTypesSizes |
+	packages.NeedModule
+
+type moduleLoad struct {
+	dir      string
+	patterns []string
+}
+
+// modulePatterns returns the patterns relative to the module in the rel directory, the patterns
+// which are not relative, i.e. 'example.com/a/...', are returned as they are.
+func modulePatterns(rel string, patterns []string) []string {
+	res := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		if pattern == "." || strings.HasPrefix(pattern, "./") {
+			pattern = "./" + path.Join(rel, pattern)
+		}
+		res = append(res, pattern)
+	}
+	return res
+}
+
+// load loads the packages of the module in the dir and fills them concurrently, the test variants
+// are filled after the packages they are testing.
+func (a *Packages) load(dir string, patterns []string, onDone func(path string), cache *analysisCache) error {
+	var changed map[string]bool
+	if cache != nil {
+		changed = a.restore(dir, patterns, cache)
+		if changed != nil && len(changed) == 0 {
+			return nil
+		}
+		if changed != nil {
+			// The external test packages are loaded by the packages they test
+			patterns = patterns[:0:0]
+			for pkgPath := range changed {
+				patterns = append(patterns, strings.TrimSuffix(pkgPath, "_test"))
+			}
+			sort.Strings(patterns)
+		}
+	}
+	pkgs, err := packages.Load(&packages.Config{
+		Mode:  loadMode,
+		Dir:   dir,
+		Tests: true,
+	}, patterns...)
+	if err != nil {
+		return err
+	}
+	a.addDependencies(pkgs)
+	a.addModules(pkgs)
+	var filled, testVariants []*packages.Package
+	for _, pkg := range pkgs {
+		// The packages with errors are kept with whatever could be loaded
+		if isStdPackage(pkg) || strings.HasSuffix(pkg.ID, ".test") || (changed != nil && !changed[pkg.PkgPath]) {
+			continue
+		}
+		if isTestVariant(pkg) {
+			testVariants = append(testVariants, pkg)
+			continue
+		}
+		filled = append(filled, pkg)
+	}
+
 	waitGroup := sync.WaitGroup{}
-	rateLimit := make(chan struct{}, 50)
-	err := filepath.Walk(rootPath, func(path string, info os.FileInfo, err error) error {
+	rateLimit := make(chan struct{}, runtime.NumCPU())
+	for _, pkg := range filled {
 		waitGroup.Add(1)
 		rateLimit <- struct{}{}
-		go func(path string, info os.FileInfo, err error) {
+		go func(pkg *packages.Package) {
 			defer waitGroup.Done()
 			defer func() {
 				<-rateLimit
 			}()
-			relPath, _ := filepath.Rel(rootPath, path)
-			if !info.IsDir() ||
-				strings.HasPrefix(relPath, ".") ||
-				strings.Contains(relPath, "vendor/",
-				) {
-				return
-			}
-			pkgs, err := packages.Load(&packages.Config{
-				Mode: packages.NeedImports | packages.NeedDeps | packages.NeedTypes |
-					packages.NeedName | packages.NeedSyntax,
-				Dir: path,
-			}, "")
-			if err != nil {
-				return
-			}
-			for _, pkg := range pkgs {
-				if len(pkg.Errors) > 0 || !strings.Contains(pkg.PkgPath, ".") {
-					continue
-				}
-				allPackages.Fill(pkg)
-				if onDone != nil {
-					onDone(path)
-				}
+			a.Fill(pkg)
+			if onDone != nil {
+				onDone(pkg.PkgPath)
 			}
-			return
-		}(path, info, err)
+		}(pkg)
+	}
+	waitGroup.Wait()
+	for _, pkg := range testVariants {
+		a.FillTest(pkg)
+	}
+	return nil
+}
+
+// restore lists the packages of the patterns, without loading their types, and restores the
+// packages which are not changed from the cache. It returns the changed packages, or nil if the
+// packages could not be listed, then all of them must be loaded.
+func (a *Packages) restore(dir string, patterns []string, cache *analysisCache) map[string]bool {
+	listed, err := packages.Load(&packages.Config{
+		Mode:  packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps | packages.NeedModule,
+		Dir:   dir,
+		Tests: true,
+	}, patterns...)
+	if err != nil {
 		return nil
+	}
+	a.addDependencies(listed)
+	a.addModules(listed)
+	changed := map[string]bool{}
+	cache.hashKeys(listed)
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	for _, pkg := range listed {
+		if isStdPackage(pkg) || strings.HasSuffix(pkg.ID, ".test") || a.byPath[pkg.PkgPath] != nil {
+			continue
+		}
+		if p, ok := cache.restore(pkg.PkgPath); ok {
+			a.addPackage(p)
+			continue
+		}
+		changed[pkg.PkgPath] = true
+	}
+	return changed
+}
+
+// addDependencies records the imports of the loaded packages and all their dependencies, except
+// the standard library which never imports the other packages.
+func (a *Packages) addDependencies(pkgs []*packages.Package) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		if isStdPackage(pkg) || strings.HasSuffix(pkg.ID, ".test") || isTestVariant(pkg) {
+			return
+		}
+		imports := make([]string, 0, len(pkg.Imports))
+		for _, ipkg := range pkg.Imports {
+			if resolved(ipkg) {
+				imports = append(imports, ipkg.PkgPath)
+			}
+		}
+		sort.Strings(imports)
+		a.dependencies[pkg.PkgPath] = imports
 	})
-	waitGroup.Wait()
-	for path, pkg := range allPackages.byPath {
-		for iPath := range allPackages.importedBy[path] {
-			pkg.importedByPackages = append(pkg.importedByPackages, iPath)
+}
+
+// isStdPackage returns true if the loaded package belongs to the standard library, which is the
+// only one without a module. The packages loaded in GOPATH mode have no module either, so they
+// are only in the standard library if their path looks like it too.
+func isStdPackage(pkg *packages.Package) bool {
+	return pkg.Module == nil && isStdPath(pkg.PkgPath)
+}
+
+// stdImports returns the paths of the standard library packages which the package imports,
+// directly or indirectly.
+func stdImports(pkg *packages.Package) map[string]bool {
+	res := map[string]bool{}
+	packages.Visit([]*packages.Package{pkg}, nil, func(p *packages.Package) {
+		if isStdPackage(p) {
+			res[p.PkgPath] = true
+		}
+	})
+	return res
+}
+
+// findModuleRoots returns the directories of the modules under the rootPath. The rootPath itself
+// is returned first, unless it is not inside a module and the modules are only nested in it.
+// Hidden, vendor and testdata directories are skipped.
+func findModuleRoots(rootPath string) ([]string, error) {
+	var nested []string
+	err := filepath.Walk(rootPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() || path == rootPath {
+			return nil
+		}
+		name := info.Name()
+		if strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "vendor" || name == "testdata" {
+			return filepath.SkipDir
 		}
+		if _, err := os.Stat(filepath.Join(path, "go.mod")); err == nil {
+			nested = append(nested, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(nested) > 0 && moduleRoot(rootPath) == "" {
+		return nested, nil
 	}
-	return err
+	return append([]string{rootPath}, nested...), nil
+}
+
+// isTestVariant returns true for the package which is recompiled with its internal test
+// files, e.g. "a/b [a/b.test]". External test packages have their own path (a/b_test) and
+// are not considered as variants.
+func isTestVariant(pkg *packages.Package) bool {
+	return strings.Contains(pkg.ID, " [") && !strings.HasSuffix(pkg.PkgPath, "_test")
 }
 
 type Packages struct {
 	byPath     map[string]*Package
 	importedBy map[string]map[string]struct{}
-	mtx        sync.RWMutex
+	modules    map[string]*Module
+	// dependencies are the imports of the loaded packages which are not in the standard library,
+	// so the import chains through the third-party packages could be found
+	dependencies map[string][]string
+	// packageModules are the module paths of the loaded packages and their dependencies
+	packageModules map[string]string
+	cacheDir       string
+	mtx            sync.RWMutex
 }
 
 func InitPackages() *Packages {
 	return &Packages{
-		byPath:     make(map[string]*Package),
-		importedBy: make(map[string]map[string]struct{}),
-		mtx:        sync.RWMutex{},
+		byPath:         make(map[string]*Package),
+		importedBy:     make(map[string]map[string]struct{}),
+		modules:        make(map[string]*Module),
+		dependencies:   make(map[string][]string),
+		packageModules: make(map[string]string),
+		mtx:            sync.RWMutex{},
 	}
 }
 
@@ -91,27 +318,19 @@ func (a *Packages) Reset() {
 	for k := range a.byPath {
 		delete(a.byPath, k)
 	}
-	a.mtx.Unlock()
-}
-
-func (a *Packages) Unmarshal(data []byte) error {
-	return json.Unmarshal(data, &a.byPath)
-}
-
-func (a *Packages) Marshal() []byte {
-	d := jsonPackages{}
-	for _, p := range a.byPath {
-		d.Packages = append(d.Packages, jsonPackage{
-			Name:       p.name,
-			Path:       p.path,
-			Imported:   p.imported,
-			ImportedBy: p.importedByPackages,
-			Funcs:      p.exportedFunctions,
-			Types:      p.exportedTypes,
-		})
+	for k := range a.importedBy {
+		delete(a.importedBy, k)
+	}
+	for k := range a.modules {
+		delete(a.modules, k)
+	}
+	for k := range a.dependencies {
+		delete(a.dependencies, k)
 	}
-	// d, _ := json.Marshal(a.byPath)
-	return []byte(d.JSON())
+	for k := range a.packageModules {
+		delete(a.packageModules, k)
+	}
+	a.mtx.Unlock()
 }
 
 func (a *Packages) Exist(pkg *packages.Package) bool {
@@ -127,6 +346,13 @@ func (a *Packages) Insert(pkg *Package) {
 	a.mtx.Unlock()
 }
 
+func (a *Packages) Len() int {
+	a.mtx.RLock()
+	n := len(a.byPath)
+	a.mtx.RUnlock()
+	return n
+}
+
 func (a *Packages) GetByPath(pkgPath string) *Package {
 	a.mtx.RLock()
 	p := a.byPath[pkgPath]
@@ -141,45 +367,129 @@ func (a *Packages) Fill(pkg *packages.Package) {
 		return
 	}
 	p := &Package{
-		name: pkg.Name,
-		path: pkg.PkgPath,
+		name:  pkg.Name,
+		path:  pkg.PkgPath,
+		files: pkg.GoFiles,
+	}
+	if len(pkg.GoFiles) > 0 {
+		p.dir = filepath.Dir(pkg.GoFiles[0])
+	}
+	if strings.HasSuffix(pkg.PkgPath, "_test") {
+		p.forTest = strings.TrimSuffix(pkg.PkgPath, "_test")
 	}
+	if pkg.Module != nil {
+		p.module, p.moduleVersion = pkg.Module.Path, pkg.Module.Version
+	}
+
+	p.errors = appendDiagnostics(nil, pkg.Errors)
 
+	a.mtx.Lock()
 	a.byPath[pkg.PkgPath] = p
 	for _, ipkg := range pkg.Imports {
+		if !resolved(ipkg) {
+			// The reason of the missing import is only reported by the imported package
+			p.errors = appendDiagnostics(p.errors, ipkg.Errors)
+			continue
+		}
 		p.imported = append(p.imported, ipkg.PkgPath)
 		if a.importedBy[ipkg.PkgPath] == nil {
 			a.importedBy[ipkg.PkgPath] = map[string]struct{}{}
 		}
 		a.importedBy[ipkg.PkgPath][pkg.PkgPath] = struct{}{}
 	}
+	a.mtx.Unlock()
+	sort.Strings(p.imported)
+	p.importPositions = importPositions(pkg, false)
 
-	for _, f := range pkg.Syntax {
-		for _, o := range f.Scope.Objects {
-			switch x := o.Decl.(type) {
-			case *ast.TypeSpec:
-				if x.Name.IsExported() {
-					p.exportedTypes = append(p.exportedTypes, x.Name.Name)
-				}
-			case *ast.FuncDecl:
-				if x.Name.IsExported() {
-					fn := strings.Builder{}
-					fn.WriteString(x.Name.Name)
-					fn.WriteString(astFuncType(x.Type))
-					p.exportedFunctions = append(p.exportedFunctions, fn.String())
+	if pkg.Types != nil {
+		p.typesPkg = pkg.Types
+		qualifier := packageQualifier(pkg.Types)
+		scope := pkg.Types.Scope()
+		for _, name := range scope.Names() {
+			switch o := scope.Lookup(name).(type) {
+			case *types.TypeName:
+				if o.Exported() {
+					t := newType(o, qualifier)
+					p.exportedTypes = append(p.exportedTypes, t)
+					if len(t.TypeParams) > 0 {
+						p.generics = append(p.generics, Generic{Name: t.Name, TypeParams: t.TypeParams})
+					}
 				}
-			case *ast.ValueSpec:
-				for _, n := range x.Names {
-					if n.IsExported() {
-						p.exportedVariables = append(p.exportedVariables, n.Name)
+			case *types.Func:
+				if o.Exported() {
+					p.exportedFunctions = append(p.exportedFunctions, funcString(o, qualifier))
+					if tps := o.Type().(*types.Signature).TypeParams(); tps.Len() > 0 {
+						p.generics = append(p.generics, Generic{
+							Name:       o.Name(),
+							Func:       true,
+							TypeParams: typeParams(tps, qualifier),
+						})
 					}
 				}
-
-			default:
+			case *types.Var:
+				if o.Exported() {
+					p.exportedVariables = append(p.exportedVariables, newValue(o, qualifier))
+				}
+			case *types.Const:
+				if o.Exported() {
+					p.exportedConstants = append(p.exportedConstants, newValue(o, qualifier))
+				}
 			}
+		}
+		p.enums = enums(pkg.Types, pkg.Syntax)
+		std := stdImports(pkg)
+		p.instantiations = instantiations(pkg, std)
+		// All the files of the external test packages are test files
+		p.references = references(pkg, p.forTest != "", std)
+		p.usage = usage(p.references, p.path, p.imported)
+	}
+	sort.Strings(p.exportedFunctions)
+}
+
+// FillTest records the imports and the references of the internal test files of the package.
+// The package itself must be filled before.
+func (a *Packages) FillTest(pkg *packages.Package) {
+	p := a.GetByPath(pkg.PkgPath)
+	if p == nil {
+		return
+	}
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	for _, f := range pkg.GoFiles {
+		if strings.HasSuffix(f, "_test.go") {
+			p.testFiles = append(p.testFiles, f)
+		}
+	}
+	for importPath, positions := range importPositions(pkg, true) {
+		if !containsString(p.imported, importPath) {
+			p.testImported = append(p.testImported, importPath)
+		}
+		p.importPositions[importPath] = append(p.importPositions[importPath], positions...)
+	}
+	sort.Strings(p.testImported)
+	p.references = append(p.references, references(pkg, true, stdImports(pkg))...)
+	p.usage = usage(p.references, p.path, append(append([]string{}, p.imported...), p.testImported...))
+	p.errors = appendDiagnostics(p.errors, pkg.Errors)
+}
 
+// importPositions returns the positions of the import specs of the package, keyed by the path
+// of the imported package. If testOnly is set, only the _test.go files are visited.
+func importPositions(pkg *packages.Package, testOnly bool) map[string][]token.Position {
+	positions := map[string][]token.Position{}
+	for _, f := range pkg.Syntax {
+		if testOnly && !strings.HasSuffix(pkg.Fset.Position(f.Pos()).Filename, "_test.go") {
+			continue
+		}
+		for _, spec := range f.Imports {
+			importPath, _ := strconv.Unquote(spec.Path.Value)
+			ipkg := pkg.Imports[importPath]
+			if ipkg == nil || !resolved(ipkg) {
+				continue
+			}
+			positions[ipkg.PkgPath] = append(positions[ipkg.PkgPath], pkg.Fset.Position(spec.Pos()))
 		}
 	}
+	return positions
 }
 
 func (a *Packages) ForEach(f func(pkgPath string, pkg *Package)) {
@@ -194,49 +504,164 @@ type Package struct {
 	mtx                sync.Mutex
 	name               string
 	path               string
+	forTest            string
+	dir                string
+	module             string
+	moduleVersion      string
+	files              []string
+	testFiles          []string
 	imported           []string
+	testImported       []string
+	importPositions    map[string][]token.Position
 	importedByPackages []string
-	exportedTypes      []string
-	exportedVariables  []string
-	exportedFunctions  []string
+	exportedTypes      []Type
+	exportedVariables  []Value
+	exportedConstants  []Value
+	enums              []Enum
+	generics           []Generic
+	instantiations     []Instantiation
+	usage              map[string][]SymbolUsage
+	errors             []Diagnostic
+	// references are not kept in the snapshots, only their number is kept in the usage
+	references []Reference
+	// typesPkg is only set for the analyzed packages, the snapshots have no type information
+	typesPkg          *types.Package
+	exportedFunctions []string
+}
+
+func (p *Package) Path() string {
+	return p.path
+}
+
+func (p *Package) Name() string {
+	return p.name
+}
+
+func (p *Package) Dir() string {
+	return p.dir
+}
+
+// Module returns the path of the module which contains the package.
+func (p *Package) Module() string {
+	return p.module
+}
+
+// ModuleVersion returns the version of the module which contains the package, the main modules
+// have no version.
+func (p *Package) ModuleVersion() string {
+	return p.moduleVersion
+}
+
+// Errors returns the load, parse and type errors of the package.
+func (p *Package) Errors() []Diagnostic {
+	return p.errors
+}
+
+// ForTest returns the path of the package under test, if this is an external test package.
+func (p *Package) ForTest() string {
+	return p.forTest
+}
+
+// ExportedTypes returns the exported types of the package sorted by name.
+func (p *Package) ExportedTypes() []Type {
+	return p.exportedTypes
+}
+
+// ExportedVariables returns the exported variables of the package sorted by name.
+func (p *Package) ExportedVariables() []Value {
+	return p.exportedVariables
+}
+
+// ExportedConstants returns the exported constants of the package sorted by name.
+func (p *Package) ExportedConstants() []Value {
+	return p.exportedConstants
+}
+
+// Enums returns the named types of the package which have constants declared by iota.
+func (p *Package) Enums() []Enum {
+	return p.enums
+}
+
+// LookupType returns the exported type with the given name.
+func (p *Package) LookupType(name string) (Type, bool) {
+	idx := sort.Search(len(p.exportedTypes), func(i int) bool {
+		return p.exportedTypes[i].Name >= name
+	})
+	if idx < len(p.exportedTypes) && p.exportedTypes[idx].Name == name {
+		return p.exportedTypes[idx], true
+	}
+	return Type{}, false
+}
+
+// testedPath returns the path of the package which is tested by this package.
+func (p *Package) testedPath() string {
+	if p.forTest != "" {
+		return p.forTest
+	}
+	return p.path
 }
 
 func (p *Package) Print() {
 	color.Green("========== %s (%s) ========", p.name, p.path)
+	if p.module != "" {
+		color.Green("Module: %s", Module{Path: p.module, Version: p.moduleVersion})
+	}
+	if len(p.errors) > 0 {
+		color.Red("Errors: (%d)", len(p.errors))
+		for idx, d := range p.errors {
+			color.Red("\t %d. %s error: %s", idx+1, d.Kind, d)
+		}
+	}
 	printPackage(p)
 	printExportedItems(p)
 }
 func printPackage(pkg *Package) {
 	color.Red("Imports: (%d)", len(pkg.imported))
-	cnt := 0
-	for p := range pkg.imported {
-		cnt++
-		color.Red("\t %d. %s", cnt, p)
+	for idx, p := range pkg.imported {
+		color.Red("\t %d. %s", idx+1, p)
 	}
 	color.HiBlue("imported By: (%d)", len(pkg.importedByPackages))
-	cnt = 0
-	for p := range pkg.importedByPackages {
-		cnt++
-		color.HiBlue("\t %d. %s", cnt, p)
+	for idx, p := range pkg.importedByPackages {
+		color.HiBlue("\t %d. %s", idx+1, p)
 	}
 }
 func printExportedItems(pkg *Package) {
 	color.HiMagenta("Exported Functions: (%d)", len(pkg.exportedFunctions))
-	cnt := 0
-	for p := range pkg.exportedFunctions {
-		cnt++
-		color.HiMagenta("\t %d. %s", cnt, p)
+	for idx, p := range pkg.exportedFunctions {
+		color.HiMagenta("\t %d. %s", idx+1, p)
 	}
 	color.HiGreen("Exported Types: (%d)", len(pkg.exportedTypes))
-	cnt = 0
-	for p := range pkg.exportedTypes {
-		cnt++
-		color.HiGreen("\t %d. %s", cnt, p)
+	for idx, t := range pkg.exportedTypes {
+		color.HiGreen("\t %d. %s", idx+1, t)
+		for _, e := range t.Embedded {
+			color.Green("\t     embeds %s", e)
+		}
+		for _, f := range t.Fields {
+			color.Green("\t     %s", f)
+		}
+		for _, m := range t.Methods {
+			color.Green("\t     %s", t.MethodString(m))
+		}
+	}
+	color.HiYellow("Exported Constants: (%d)", len(pkg.exportedConstants))
+	for idx, c := range pkg.exportedConstants {
+		color.HiYellow("\t %d. %s", idx+1, c)
+	}
+	color.HiCyan("Enums: (%d)", len(pkg.enums))
+	for idx, e := range pkg.enums {
+		color.HiCyan("\t %d. %s: %s", idx+1, e.Type, strings.Join(e.Values, ", "))
 	}
 	color.HiRed("Exported Variables: (%d)", len(pkg.exportedVariables))
-	cnt = 0
-	for p := range pkg.exportedVariables {
-		cnt++
-		color.HiRed("\t %d. %s", cnt, p)
+	for idx, v := range pkg.exportedVariables {
+		color.HiRed("\t %d. %s", idx+1, v)
+	}
+}
+
+func containsString(items []string, item string) bool {
+	for _, i := range items {
+		if i == item {
+			return true
+		}
 	}
+	return false
 }
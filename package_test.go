@@ -0,0 +1,128 @@
+package godeep
+
+import (
+	"fmt"
+	"golang.org/x/tools/go/packages"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeModule writes the files of a module into a temporary directory, the paths are relative
+// to the module root.
+func writeModule(tb testing.TB, files map[string]string) string {
+	tb.Helper()
+	dir := tb.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			tb.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			tb.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// analyze loads all the packages of the module without the cache.
+func analyze(tb testing.TB, dir string) *Packages {
+	tb.Helper()
+	a := InitPackages()
+	if err := FindPackages(a, dir, func(string) {}); err != nil {
+		tb.Fatal(err)
+	}
+	return a
+}
+
+// syntheticTree returns the files of a module with n packages, every package imports the two
+// packages before it and a few heavy standard packages, so the shared dependencies dominate the
+// type checking like they do in a monorepo.
+func syntheticTree(n int) map[string]string {
+	files := map[string]string{
+		"go.mod": "module example.com/large\n\ngo 1.22\n",
+	}
+	for i := 0; i < n; i++ {
+		imports := []string{"encoding/json", "net/http", "text/template"}
+		uses := "var _ = json.Marshal\nvar _ = http.Get\nvar _ = template.New\n"
+		for _, dep := range []int{i - 1, i / 2} {
+			if dep >= 0 && dep < i {
+				imports = append(imports, fmt.Sprintf("example.com/large/p%03d", dep))
+				uses += fmt.Sprintf("var _ = p%03d.Value\n", dep)
+			}
+		}
+		src := fmt.Sprintf("package p%03d\n\nimport (\n", i)
+		for _, imp := range imports {
+			src += fmt.Sprintf("\t%q\n", imp)
+		}
+		src += ")\n\n" + uses + "\nvar Value = 1\n\nfunc Do() error { return nil }\n"
+		files[fmt.Sprintf("p%03d/p.go", i)] = src
+	}
+	return files
+}
+
+// benchmarkPackages is the size of the synthetic tree, loading it per directory already takes a
+// minute on a single core.
+const benchmarkPackages = 20
+
+func BenchmarkFindPackages(b *testing.B) {
+	dir := writeModule(b, syntheticTree(benchmarkPackages))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		analyze(b, dir)
+	}
+}
+
+// BenchmarkLoadPerDirectory is the loading of the older versions, which called packages.Load in
+// every directory, so the shared dependencies were type-checked again for every package. The
+// directories are loaded one by one, the older versions loaded them concurrently which divides
+// the time by the number of cores at most.
+func BenchmarkLoadPerDirectory(b *testing.B) {
+	dir := writeModule(b, syntheticTree(benchmarkPackages))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for p := 0; p < benchmarkPackages; p++ {
+			_, err := packages.Load(&packages.Config{
+				Mode:  loadMode,
+				Dir:   filepath.Join(dir, fmt.Sprintf("p%03d", p)),
+				Tests: true,
+			}, ".")
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func TestFindPackagesWithoutDotInModulePath(t *testing.T) {
+	dir := writeModule(t, map[string]string{
+		"go.mod": "module myapp\n\ngo 1.22\n",
+		"main.go": `package main
+
+import (
+	"fmt"
+
+	"myapp/lib"
+)
+
+func main() { fmt.Println(lib.Map(1)) }
+`,
+		"lib/lib.go": `package lib
+
+func Map[T any](v T) T { return v }
+`,
+	})
+	a := analyze(t, dir)
+	if a.Len() != 2 || a.GetByPath("myapp/lib") == nil {
+		t.Fatalf("expected the packages of the module, got %d packages", a.Len())
+	}
+	if kind := a.nodeKind("myapp/lib"); kind != NodeInternal {
+		t.Errorf("expected myapp/lib to be internal, got %v", kind)
+	}
+	if kind := a.nodeKind("fmt"); kind != NodeStd {
+		t.Errorf("expected fmt to be in the standard library, got %v", kind)
+	}
+	if usages := a.Generics("myapp/lib"); len(usages) != 1 || len(usages[0].Sites) != 1 {
+		t.Errorf("expected the instantiation of myapp/lib.Map, got %v", usages)
+	}
+}
@@ -0,0 +1,64 @@
+package godeep
+
+import (
+	"go/token"
+)
+
+// ImportStep is a single edge of an import chain, with the positions of the import
+// statements in the importing package.
+type ImportStep struct {
+	From      string
+	To        string
+	Positions []token.Position
+}
+
+type ImportPath []ImportStep
+
+// Paths returns the shortest import chain from 'from' to 'to' followed by at most k shortest
+// alternative chains. It returns nil if 'from' does not depend on 'to'. The chains could pass
+// through the third-party packages, but not through the standard library, and only the steps
+// from the analyzed packages have the positions of their import statements.
+func (a *Packages) Paths(from, to string, k int) []ImportPath {
+	a.mtx.RLock()
+	defer a.mtx.RUnlock()
+
+	var paths []ImportPath
+	for _, nodes := range a.packageGraph().kShortestPaths(from, to, k+1) {
+		path := make(ImportPath, 0, len(nodes)-1)
+		for i := 0; i < len(nodes)-1; i++ {
+			step := ImportStep{
+				From: nodes[i],
+				To:   nodes[i+1],
+			}
+			if p := a.byPath[step.From]; p != nil {
+				step.Positions = p.importPositions[step.To]
+			}
+			path = append(path, step)
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// packageGraph builds the graph of the imported edges of all the packages and their third-party
+// dependencies, the external test packages are kept as separate nodes.
+func (a *Packages) packageGraph() graph {
+	g := graph{}
+	for pkgPath, p := range a.byPath {
+		if _, ok := g[pkgPath]; !ok {
+			g[pkgPath] = nil
+		}
+		for _, to := range p.imported {
+			g.addEdge(pkgPath, to)
+		}
+	}
+	for pkgPath, imports := range a.dependencies {
+		if a.byPath[pkgPath] != nil {
+			continue
+		}
+		for _, to := range imports {
+			g.addEdge(pkgPath, to)
+		}
+	}
+	return g
+}
@@ -0,0 +1,57 @@
+package godeep
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestPathsThroughThirdParty(t *testing.T) {
+	dir := writeModule(t, map[string]string{
+		"main/go.mod": "module example.com/s\n\ngo 1.22\n\nrequire example.com/lib v0.0.0\n\nreplace example.com/lib => ../lib\n",
+		"main/a/a.go": `package a
+
+import "example.com/lib/log"
+
+var Logger = log.New()
+`,
+		"lib/go.mod": "module example.com/lib\n\ngo 1.22\n",
+		"lib/log/log.go": `package log
+
+import "example.com/lib/buf"
+
+type Logger struct{ b buf.Buffer }
+
+func New() *Logger { return &Logger{} }
+`,
+		"lib/buf/buf.go": `package buf
+
+type Buffer []byte
+`,
+	})
+	a := analyze(t, filepath.Join(dir, "main"))
+
+	paths := a.Paths("example.com/s/a", "example.com/lib/buf", 0)
+	if len(paths) != 1 {
+		t.Fatalf("expected a single chain, got %v", paths)
+	}
+	var chain []string
+	for _, step := range paths[0] {
+		chain = append(chain, step.From+" -> "+step.To)
+	}
+	expected := []string{"example.com/s/a -> example.com/lib/log", "example.com/lib/log -> example.com/lib/buf"}
+	if len(chain) != len(expected) || chain[0] != expected[0] || chain[1] != expected[1] {
+		t.Errorf("expected %v, got %v", expected, chain)
+	}
+	if len(paths[0][0].Positions) != 1 {
+		t.Errorf("expected the position of the import in example.com/s/a, got %v", paths[0][0].Positions)
+	}
+
+	// The chains through the dependencies are kept in the snapshots
+	b := InitPackages()
+	if err := b.Unmarshal(a.Marshal()); err != nil {
+		t.Fatal(err)
+	}
+	if paths := b.Paths("example.com/s/a", "example.com/lib/buf", 0); len(paths) != 1 {
+		t.Errorf("expected a single chain after Unmarshal, got %v", paths)
+	}
+}
@@ -0,0 +1,178 @@
+// Code generated by qtc from "plantuml.qtpl". DO NOT EDIT.
+// See https://github.com/valyala/quicktemplate for details.
+
+// PlantUML component diagram rendering of the import graph
+
+//line plantuml.qtpl:3
+package godeep
+
+//line plantuml.qtpl:3
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line plantuml.qtpl:3
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+//line plantuml.qtpl:3
+func (d *diagram) StreamPlantUML(qw422016 *qt422016.Writer) {
+//line plantuml.qtpl:3
+	qw422016.N().S(`@startuml`)
+//line plantuml.qtpl:4
+	qw422016.N().S(`
+`)
+//line plantuml.qtpl:4
+	qw422016.N().S(`left to right direction`)
+//line plantuml.qtpl:5
+	qw422016.N().S(`
+`)
+//line plantuml.qtpl:6
+	for _, c := range d.Clusters {
+//line plantuml.qtpl:7
+		if c.Name != "" {
+//line plantuml.qtpl:7
+			qw422016.N().S(`package`)
+//line plantuml.qtpl:8
+			qw422016.N().S(` `)
+//line plantuml.qtpl:8
+			qw422016.N().Q(c.Name)
+//line plantuml.qtpl:8
+			qw422016.N().S(` `)
+//line plantuml.qtpl:8
+			qw422016.N().S(`{`)
+//line plantuml.qtpl:8
+			qw422016.N().S(`
+`)
+//line plantuml.qtpl:9
+		}
+//line plantuml.qtpl:10
+		for _, n := range c.Nodes {
+//line plantuml.qtpl:10
+			qw422016.N().S(`rectangle`)
+//line plantuml.qtpl:11
+			qw422016.N().S(` `)
+//line plantuml.qtpl:11
+			qw422016.N().Q(n.Path)
+//line plantuml.qtpl:11
+			qw422016.N().S(` `)
+//line plantuml.qtpl:11
+			qw422016.N().S(`as`)
+//line plantuml.qtpl:11
+			qw422016.N().S(` `)
+//line plantuml.qtpl:11
+			qw422016.E().S(d.id(n.Path))
+//line plantuml.qtpl:11
+			qw422016.N().S(` `)
+//line plantuml.qtpl:12
+			switch n.Kind {
+//line plantuml.qtpl:13
+			case NodeInternal:
+//line plantuml.qtpl:13
+				qw422016.N().S(`#B3D4FF`)
+//line plantuml.qtpl:15
+			case NodeThirdParty:
+//line plantuml.qtpl:15
+				qw422016.N().S(`#FFE380`)
+//line plantuml.qtpl:17
+			case NodeStd:
+//line plantuml.qtpl:17
+				qw422016.N().S(`#EBECF0`)
+//line plantuml.qtpl:19
+			}
+//line plantuml.qtpl:20
+			if n.Broken {
+//line plantuml.qtpl:20
+				qw422016.N().S(`;line:DE350B;line.dashed`)
+//line plantuml.qtpl:22
+			}
+//line plantuml.qtpl:23
+			qw422016.N().S(`
+`)
+//line plantuml.qtpl:24
+		}
+//line plantuml.qtpl:25
+		if c.Name != "" {
+//line plantuml.qtpl:25
+			qw422016.N().S(`}`)
+//line plantuml.qtpl:26
+			qw422016.N().S(`
+`)
+//line plantuml.qtpl:27
+		}
+//line plantuml.qtpl:28
+	}
+//line plantuml.qtpl:29
+	for _, e := range d.Edges {
+//line plantuml.qtpl:30
+		qw422016.E().S(d.id(e.From))
+//line plantuml.qtpl:30
+		qw422016.N().S(` `)
+//line plantuml.qtpl:31
+		if e.Cycle {
+//line plantuml.qtpl:31
+			qw422016.N().S(`-[#DE350B,bold]->`)
+//line plantuml.qtpl:33
+		} else {
+//line plantuml.qtpl:33
+			qw422016.N().S(`-->`)
+//line plantuml.qtpl:35
+		}
+//line plantuml.qtpl:36
+		qw422016.N().S(` `)
+//line plantuml.qtpl:36
+		qw422016.E().S(d.id(e.To))
+//line plantuml.qtpl:37
+		if e.Weight > 0 {
+//line plantuml.qtpl:38
+			qw422016.N().S(` `)
+//line plantuml.qtpl:38
+			qw422016.N().S(`:`)
+//line plantuml.qtpl:38
+			qw422016.N().S(` `)
+//line plantuml.qtpl:38
+			qw422016.N().D(e.Weight)
+//line plantuml.qtpl:39
+		}
+//line plantuml.qtpl:40
+		qw422016.N().S(`
+`)
+//line plantuml.qtpl:41
+	}
+//line plantuml.qtpl:41
+	qw422016.N().S(`@enduml`)
+//line plantuml.qtpl:42
+	qw422016.N().S(`
+`)
+//line plantuml.qtpl:43
+}
+
+//line plantuml.qtpl:43
+func (d *diagram) WritePlantUML(qq422016 qtio422016.Writer) {
+//line plantuml.qtpl:43
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line plantuml.qtpl:43
+	d.StreamPlantUML(qw422016)
+//line plantuml.qtpl:43
+	qt422016.ReleaseWriter(qw422016)
+//line plantuml.qtpl:43
+}
+
+//line plantuml.qtpl:43
+func (d *diagram) PlantUML() string {
+//line plantuml.qtpl:43
+	qb422016 := qt422016.AcquireByteBuffer()
+//line plantuml.qtpl:43
+	d.WritePlantUML(qb422016)
+//line plantuml.qtpl:43
+	qs422016 := string(qb422016.B)
+//line plantuml.qtpl:43
+	qt422016.ReleaseByteBuffer(qb422016)
+//line plantuml.qtpl:43
+	return qs422016
+//line plantuml.qtpl:43
+}
@@ -0,0 +1,101 @@
+package godeep
+
+import (
+	"encoding/json"
+)
+
+type reportNode struct {
+	ID         string   `json:"id"`
+	Path       string   `json:"path"`
+	Name       string   `json:"name,omitempty"`
+	Kind       string   `json:"kind"`
+	Cluster    string   `json:"cluster,omitempty"`
+	Imported   []string `json:"imported"`
+	ImportedBy []string `json:"importedBy"`
+	Funcs      []string `json:"funcs"`
+	Types      []string `json:"types"`
+	Fields     []string `json:"fields"`
+	Methods    []string `json:"methods"`
+	Constants  []string `json:"constants"`
+	Variables  []string `json:"variables"`
+	Errors     []string `json:"errors,omitempty"`
+}
+
+type reportEdge struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Cycle  bool   `json:"cycle"`
+	Weight int    `json:"weight"`
+}
+
+type reportData struct {
+	Nodes []reportNode `json:"nodes"`
+	Edges []reportEdge `json:"edges"`
+}
+
+// htmlReport is rendered by the html template, Data is the json encoded reportData which is
+// embedded in the page.
+type htmlReport struct {
+	Data []byte
+}
+
+// HTML renders a self-contained interactive HTML page of the import graph, the page does not
+// need any server or network access.
+func (a *Packages) HTML(opt DiagramOptions) ([]byte, error) {
+	d := a.diagram(opt)
+
+	a.mtx.RLock()
+	data := reportData{}
+	for _, c := range d.Clusters {
+		for _, n := range c.Nodes {
+			node := reportNode{
+				ID:      d.id(n.Path),
+				Path:    n.Path,
+				Kind:    n.Kind.String(),
+				Cluster: c.Name,
+			}
+			if p := a.byPath[n.Path]; p != nil {
+				node.Name = p.name
+				node.Imported = p.imported
+				node.ImportedBy = p.importedByPackages
+				node.Funcs = p.exportedFunctions
+				node.Types = typeStrings(p.exportedTypes)
+				node.Fields = fieldStrings(p.exportedTypes)
+				node.Methods = methodStrings(p.exportedTypes)
+				node.Constants = valueStrings(p.exportedConstants)
+				node.Variables = valueStrings(p.exportedVariables)
+				for _, d := range p.errors {
+					node.Errors = append(node.Errors, d.String())
+				}
+			} else if m := a.modules[n.Path]; opt.Modules && m != nil {
+				for _, e := range a.moduleEdges() {
+					switch m.Path {
+					case e.From:
+						node.Imported = append(node.Imported, e.To)
+					case e.To:
+						node.ImportedBy = append(node.ImportedBy, e.From)
+					}
+				}
+			}
+			data.Nodes = append(data.Nodes, node)
+		}
+	}
+	a.mtx.RUnlock()
+	for _, e := range d.Edges {
+		data.Edges = append(data.Edges, reportEdge{
+			From:   d.id(e.From),
+			To:     d.id(e.To),
+			Cycle:  e.Cycle,
+			Weight: e.Weight,
+		})
+	}
+
+	// json.Marshal escapes '<' and '>', so the data is safe to be embedded in a script tag
+	r := &htmlReport{}
+	var err error
+	r.Data, err = json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(r.HTML()), nil
+}
@@ -0,0 +1,172 @@
+package godeep
+
+import (
+	"fmt"
+	"go/token"
+	"gopkg.in/yaml.v2"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Rules is the architecture rules, usually loaded from the .godeep.yaml file:
+//
+//	tests: false
+//	rules:
+//	  - name: domain must not import infra
+//	    from: [example.com/app/domain/...]
+//	    deny: [example.com/app/infra/...]
+//	  - name: only cmd may import wiring
+//	    to: [example.com/app/internal/wiring]
+//	    allow: [example.com/app/cmd/...]
+//	deadapi:
+//	  allow: [example.com/app/sdk/..., example.com/app/pkg/log.Logger]
+//
+// Patterns are import paths in which '...' matches any string and '*' matches any string
+// without a slash. As in the go tool, 'a/...' matches 'a' itself too.
+type Rules struct {
+	// Tests if set, the imports of the test files are checked too, the external test packages
+	// are checked as the packages they test.
+	Tests   bool         `yaml:"tests"`
+	Rules   []Rule       `yaml:"rules"`
+	DeadAPI DeadAPIRules `yaml:"deadapi"`
+}
+
+// Rule selects the import edges either by the importing packages (From) or by the imported
+// packages (To), then the other side of the selected edges must match Allow, if it is set, and
+// must not match Deny.
+type Rule struct {
+	Name  string   `yaml:"name"`
+	From  []string `yaml:"from"`
+	To    []string `yaml:"to"`
+	Allow []string `yaml:"allow"`
+	Deny  []string `yaml:"deny"`
+
+	from, to, allow, deny []*regexp.Regexp
+}
+
+type Violation struct {
+	Rule      string
+	From      string
+	To        string
+	Positions []token.Position
+}
+
+func LoadRules(filename string) (*Rules, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRules(data)
+}
+
+func ParseRules(data []byte) (*Rules, error) {
+	rules := &Rules{}
+	err := yaml.UnmarshalStrict(data, rules)
+	if err != nil {
+		return nil, err
+	}
+	for idx := range rules.Rules {
+		r := &rules.Rules[idx]
+		if r.Name == "" {
+			r.Name = fmt.Sprintf("rule #%d", idx+1)
+		}
+		if (len(r.From) == 0) == (len(r.To) == 0) {
+			return nil, fmt.Errorf("%s: exactly one of 'from' or 'to' must be set", r.Name)
+		}
+		if len(r.Allow) == 0 && len(r.Deny) == 0 {
+			return nil, fmt.Errorf("%s: at least one of 'allow' or 'deny' must be set", r.Name)
+		}
+		r.from = compilePatterns(r.From)
+		r.to = compilePatterns(r.To)
+		r.allow = compilePatterns(r.Allow)
+		r.deny = compilePatterns(r.Deny)
+	}
+	return rules, nil
+}
+
+// Check evaluates the rules against every import edge and returns the violations sorted by the
+// importing package. The external test packages, i.e. 'example.com/app/domain_test', are matched
+// by the path of the package they test.
+func (a *Packages) Check(rules *Rules) []Violation {
+	a.mtx.RLock()
+	defer a.mtx.RUnlock()
+
+	var violations []Violation
+	for pkgPath, p := range a.byPath {
+		if p.forTest != "" && !rules.Tests {
+			continue
+		}
+		imported := p.imported
+		if rules.Tests {
+			imported = append(imported[:len(imported):len(imported)], p.testImported...)
+		}
+		for _, to := range imported {
+			for _, r := range rules.Rules {
+				if !r.violated(p.testedPath(), to) {
+					continue
+				}
+				violations = append(violations, Violation{
+					Rule:      r.Name,
+					From:      pkgPath,
+					To:        to,
+					Positions: p.importPositions[to],
+				})
+			}
+		}
+	}
+	sort.Slice(violations, func(i, j int) bool {
+		if violations[i].From != violations[j].From {
+			return violations[i].From < violations[j].From
+		}
+		if violations[i].To != violations[j].To {
+			return violations[i].To < violations[j].To
+		}
+		return violations[i].Rule < violations[j].Rule
+	})
+	return violations
+}
+
+func (r *Rule) violated(from, to string) bool {
+	other := to
+	if len(r.from) > 0 {
+		if !matchAny(r.from, from) {
+			return false
+		}
+	} else {
+		if !matchAny(r.to, to) {
+			return false
+		}
+		other = from
+	}
+	if len(r.allow) > 0 && !matchAny(r.allow, other) {
+		return true
+	}
+	return matchAny(r.deny, other)
+}
+
+// compilePatterns converts the path patterns to regular expressions, following the
+// semantics of the package patterns of the go tool.
+func compilePatterns(patterns []string) []*regexp.Regexp {
+	res := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		re := regexp.QuoteMeta(p)
+		re = strings.Replace(re, `\.\.\.`, `.*`, -1)
+		re = strings.Replace(re, `\*`, `[^/]*`, -1)
+		if strings.HasSuffix(re, `/.*`) {
+			re = strings.TrimSuffix(re, `/.*`) + `(/.*)?`
+		}
+		res = append(res, regexp.MustCompile(fmt.Sprintf("^%s$", re)))
+	}
+	return res
+}
+
+func matchAny(patterns []*regexp.Regexp, pkgPath string) bool {
+	for _, p := range patterns {
+		if p.MatchString(pkgPath) {
+			return true
+		}
+	}
+	return false
+}
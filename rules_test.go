@@ -0,0 +1,122 @@
+package godeep
+
+import (
+	"testing"
+)
+
+func TestCompilePatterns(t *testing.T) {
+	tests := []struct {
+		pattern string
+		pkgPath string
+		match   bool
+	}{
+		{"example.com/a", "example.com/a", true},
+		{"example.com/a", "example.com/a/b", false},
+		{"example.com/a/...", "example.com/a", true},
+		{"example.com/a/...", "example.com/a/b/c", true},
+		{"example.com/a/...", "example.com/ab", false},
+		{"example.com/.../infra", "example.com/x/y/infra", true},
+		{"example.com/.../infra", "example.com/x/infra/z", false},
+		{"example.com/*/infra", "example.com/x/infra", true},
+		{"example.com/*/infra", "example.com/x/y/infra", false},
+		{"example.com/a*", "example.com/ab", true},
+		{"example.com/a*", "example.com/a/b", false},
+		{"example.com/a.b", "example.com/aXb", false},
+	}
+	for _, tt := range tests {
+		if match := matchAny(compilePatterns([]string{tt.pattern}), tt.pkgPath); match != tt.match {
+			t.Errorf("%s matching %s: expected %v, got %v", tt.pattern, tt.pkgPath, tt.match, match)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	a := InitPackages()
+	err := a.Unmarshal([]byte(`{"version": 1, "packages": [
+		{"name": "domain", "path": "example.com/app/domain", "imported": ["example.com/app/infra/db"],
+		 "testImported": ["example.com/app/internal/wiring"]},
+		{"name": "domain_test", "path": "example.com/app/domain_test", "forTest": "example.com/app/domain",
+		 "imported": ["example.com/app/infra/cache", "example.com/app/internal/wiring"]},
+		{"name": "main", "path": "example.com/app/cmd/app", "imported": ["example.com/app/internal/wiring"]},
+		{"name": "db", "path": "example.com/app/infra/db"},
+		{"name": "cache", "path": "example.com/app/infra/cache"},
+		{"name": "wiring", "path": "example.com/app/internal/wiring"}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name       string
+		rules      string
+		violations []string
+	}{
+		{
+			name: "deny",
+			rules: `rules:
+  - name: domain must not import infra
+    from: [example.com/app/domain/...]
+    deny: [example.com/app/infra/...]`,
+			violations: []string{"example.com/app/domain -> example.com/app/infra/db"},
+		},
+		{
+			name: "deny in tests",
+			rules: `tests: true
+rules:
+  - name: domain must not import infra
+    from: [example.com/app/domain/...]
+    deny: [example.com/app/infra/...]`,
+			violations: []string{
+				"example.com/app/domain -> example.com/app/infra/db",
+				"example.com/app/domain_test -> example.com/app/infra/cache",
+			},
+		},
+		{
+			name: "allow",
+			rules: `rules:
+  - name: only cmd may import wiring
+    to: [example.com/app/internal/wiring]
+    allow: [example.com/app/cmd/...]`,
+		},
+		{
+			name: "allow in tests",
+			rules: `tests: true
+rules:
+  - name: only cmd may import wiring
+    to: [example.com/app/internal/wiring]
+    allow: [example.com/app/cmd/...]`,
+			violations: []string{
+				"example.com/app/domain -> example.com/app/internal/wiring",
+				"example.com/app/domain_test -> example.com/app/internal/wiring",
+			},
+		},
+		{
+			name: "allowed test package",
+			rules: `tests: true
+rules:
+  - name: only cmd and domain may import wiring
+    to: [example.com/app/internal/wiring]
+    allow: [example.com/app/cmd/..., example.com/app/domain]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := ParseRules([]byte(tt.rules))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var violations []string
+			for _, v := range a.Check(rules) {
+				violations = append(violations, v.From+" -> "+v.To)
+			}
+			if len(violations) != len(tt.violations) {
+				t.Fatalf("expected %v, got %v", tt.violations, violations)
+			}
+			for idx := range violations {
+				if violations[idx] != tt.violations[idx] {
+					t.Errorf("expected %v, got %v", tt.violations, violations)
+					break
+				}
+			}
+		})
+	}
+}
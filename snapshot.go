@@ -0,0 +1,399 @@
+package godeep
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"sort"
+)
+
+// SnapshotVersion is the version of the snapshot schema written by Packages.Marshal. It must be
+// increased whenever the schema changes in a way which older versions could not read, and
+// Packages.Unmarshal must keep reading the older versions.
+const SnapshotVersion = 1
+
+// Snapshot is the on-disk format of the analyzed packages, written by Packages.Marshal and read
+// by Packages.Unmarshal. It is a json document:
+//
+//	{
+//	  "version": 1,
+//	  "packages": [
+//	    {
+//	      "name": "b",
+//	      "path": "example.com/a/b",
+//	      "forTest": "",                      // path of the tested package for _test packages
+//	      "module": "example.com/a",
+//	      "moduleVersion": "",                 // not set for the main modules
+//	      "dir": "/src/a/b",
+//	      "files": ["/src/a/b/b.go"],
+//	      "testFiles": ["/src/a/b/b_test.go"],  // internal test files
+//	      "imported": ["example.com/a/c", "fmt"],
+//	      "testImported": ["testing"],         // imported only by the test files
+//	      "importedBy": ["example.com/a"],
+//	      "importPositions": {"fmt": [{"file": "/src/a/b/b.go", "line": 3, "column": 8}]},
+//	      "exportedFunctions": ["New() *Client"],
+//	      "exportedTypes": [
+//	        {"name": "Client", "methods": [{"name": "Do", "signature": "(req *Request) error", "pointer": true}]}
+//	      ],
+//	      "exportedConstants": [{"name": "ModeFast", "type": "Mode", "value": "0"}],
+//	      "exportedVariables": [{"name": "ErrClosed", "type": "error"}],
+//	      "enums": [{"type": "Mode", "values": ["ModeFast", "ModeSafe"]}],
+//	      "generics": [{"name": "Map", "func": true, "typeParams": [{"name": "T", "constraint": "any"}]}],
+//	      "instantiations": [{"package": "example.com/a/c", "name": "Set", "typeArgs": ["string"], "count": 2}],
+//	      "usage": {"fmt": [{"name": "Errorf", "count": 2}], "example.com/a/c": [{"name": "Client.Do", "count": 1}]},
+//	      "errors": [{"kind": "type", "position": "/src/a/b/b.go:7:9", "message": "undefined: x"}]
+//	    }
+//	  ],
+//	  "modules": [
+//	    {"path": "example.com/a", "dir": "/src/a", "main": true, "requires": {"example.com/d": "v1.2.0"},
+//	     "imports": {"example.com/d": 3}},
+//	    {"path": "example.com/d", "version": "v1.2.0"},
+//	    {"path": "example.com/e", "version": "v0.3.0", "replace": "../e"}
+//	  ],
+//	  "dependencies": {"example.com/d/log": ["example.com/d/internal/buf"]}  // third-party imports
+//	}
+//
+// Packages are sorted by their path and every list is sorted, so snapshots of the same code are
+// identical and could be compared by diff tools.
+type Snapshot struct {
+	Version  int               `json:"version"`
+	Packages []SnapshotPackage `json:"packages"`
+	Modules  []Module          `json:"modules,omitempty"`
+	// Dependencies are the imports of the third-party packages which are not analyzed
+	Dependencies map[string][]string `json:"dependencies,omitempty"`
+}
+
+type SnapshotPackage struct {
+	Name              string                `json:"name"`
+	Path              string                `json:"path"`
+	ForTest           string                `json:"forTest,omitempty"`
+	Module            string                `json:"module,omitempty"`
+	ModuleVersion     string                `json:"moduleVersion,omitempty"`
+	Dir               string                `json:"dir,omitempty"`
+	Files             []string              `json:"files,omitempty"`
+	TestFiles         []string              `json:"testFiles,omitempty"`
+	Imported          []string              `json:"imported,omitempty"`
+	TestImported      []string              `json:"testImported,omitempty"`
+	ImportedBy        []string              `json:"importedBy,omitempty"`
+	ImportPositions   map[string][]Position `json:"importPositions,omitempty"`
+	ExportedFunctions []string              `json:"exportedFunctions,omitempty"`
+	ExportedTypes     []Type                `json:"exportedTypes,omitempty"`
+	ExportedConstants []Value               `json:"exportedConstants,omitempty"`
+	ExportedVariables []Value               `json:"exportedVariables,omitempty"`
+	Enums             []Enum                `json:"enums,omitempty"`
+	Generics          []Generic             `json:"generics,omitempty"`
+	Instantiations    []Instantiation       `json:"instantiations,omitempty"`
+	// Usage are the referenced symbols of every imported package
+	Usage  map[string][]SymbolUsage `json:"usage,omitempty"`
+	Errors []Diagnostic             `json:"errors,omitempty"`
+}
+
+type Position struct {
+	Filename string `json:"file"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column,omitempty"`
+}
+
+// Marshal encodes the packages as the latest version of the Snapshot.
+func (a *Packages) Marshal() []byte {
+	a.mtx.RLock()
+	s := Snapshot{
+		Version:  SnapshotVersion,
+		Packages: make([]SnapshotPackage, 0, len(a.byPath)),
+	}
+	for _, p := range a.byPath {
+		s.Packages = append(s.Packages, newSnapshotPackage(p))
+	}
+	for _, m := range a.modules {
+		s.Modules = append(s.Modules, *m)
+	}
+	for pkgPath, imports := range a.dependencies {
+		if a.byPath[pkgPath] != nil {
+			continue
+		}
+		if s.Dependencies == nil {
+			s.Dependencies = map[string][]string{}
+		}
+		s.Dependencies[pkgPath] = imports
+	}
+	a.mtx.RUnlock()
+	sort.Slice(s.Modules, func(i, j int) bool {
+		return s.Modules[i].Path < s.Modules[j].Path
+	})
+	sort.Slice(s.Packages, func(i, j int) bool {
+		return s.Packages[i].Path < s.Packages[j].Path
+	})
+
+	// Encoding the snapshot could not fail, it only has strings, ints, slices and maps. HTML
+	// escaping is disabled to keep the signatures with channels readable.
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(s)
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+}
+
+// Unmarshal replaces the packages with the decoded snapshot. Besides the versioned snapshots,
+// the files written by older versions of godeep are migrated: the {"packages": [...]} documents
+// which were written by the json template, and the documents which map every package path to
+// its package.
+func (a *Packages) Unmarshal(data []byte) error {
+	var header map[string]json.RawMessage
+	if err := json.Unmarshal(removeTrailingCommas(data), &header); err != nil {
+		return err
+	}
+
+	var (
+		s   *Snapshot
+		err error
+	)
+	_, hasVersion := header["version"]
+	_, hasPackages := header["packages"]
+	switch {
+	case hasVersion:
+		var version int
+		if err = json.Unmarshal(header["version"], &version); err != nil {
+			return err
+		}
+		if version > SnapshotVersion {
+			return fmt.Errorf("snapshot version %d is newer than the supported version %d", version, SnapshotVersion)
+		}
+		s = &Snapshot{}
+		err = json.Unmarshal(data, s)
+	case hasPackages:
+		s, err = migrateTemplateSnapshot(removeTrailingCommas(data))
+	default:
+		s, err = migrateMapSnapshot(data)
+	}
+	if err != nil {
+		return err
+	}
+
+	a.Reset()
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	for _, sp := range s.Packages {
+		a.addPackage(newPackage(sp))
+	}
+	for idx := range s.Modules {
+		m := s.Modules[idx]
+		a.modules[m.Path] = &m
+	}
+	for pkgPath, imports := range s.Dependencies {
+		a.dependencies[pkgPath] = imports
+	}
+	return nil
+}
+
+func newSnapshotPackage(p *Package) SnapshotPackage {
+	sp := SnapshotPackage{
+		Name:              p.name,
+		Path:              p.path,
+		ForTest:           p.forTest,
+		Module:            p.module,
+		ModuleVersion:     p.moduleVersion,
+		Dir:               p.dir,
+		Files:             p.files,
+		TestFiles:         p.testFiles,
+		Imported:          p.imported,
+		TestImported:      p.testImported,
+		ImportedBy:        p.importedByPackages,
+		ExportedFunctions: p.exportedFunctions,
+		ExportedTypes:     p.exportedTypes,
+		ExportedConstants: p.exportedConstants,
+		ExportedVariables: p.exportedVariables,
+		Enums:             p.enums,
+		Generics:          p.generics,
+		Instantiations:    p.instantiations,
+		Usage:             p.usage,
+		Errors:            p.errors,
+	}
+	if len(p.importPositions) > 0 {
+		sp.ImportPositions = make(map[string][]Position, len(p.importPositions))
+		for importPath, positions := range p.importPositions {
+			for _, pos := range positions {
+				sp.ImportPositions[importPath] = append(sp.ImportPositions[importPath], Position{
+					Filename: pos.Filename,
+					Line:     pos.Line,
+					Column:   pos.Column,
+				})
+			}
+		}
+	}
+	return sp
+}
+
+func newPackage(sp SnapshotPackage) *Package {
+	p := &Package{
+		name:               sp.Name,
+		path:               sp.Path,
+		forTest:            sp.ForTest,
+		module:             sp.Module,
+		moduleVersion:      sp.ModuleVersion,
+		dir:                sp.Dir,
+		files:              sp.Files,
+		testFiles:          sp.TestFiles,
+		imported:           sp.Imported,
+		testImported:       sp.TestImported,
+		importedByPackages: sp.ImportedBy,
+		importPositions:    map[string][]token.Position{},
+		exportedFunctions:  sp.ExportedFunctions,
+		exportedTypes:      sp.ExportedTypes,
+		exportedConstants:  sp.ExportedConstants,
+		exportedVariables:  sp.ExportedVariables,
+		enums:              sp.Enums,
+		generics:           sp.Generics,
+		instantiations:     sp.Instantiations,
+		usage:              sp.Usage,
+		errors:             sp.Errors,
+	}
+	for importPath, positions := range sp.ImportPositions {
+		for _, pos := range positions {
+			p.importPositions[importPath] = append(p.importPositions[importPath], token.Position{
+				Filename: pos.Filename,
+				Line:     pos.Line,
+				Column:   pos.Column,
+			})
+		}
+	}
+	return p
+}
+
+// addPackage inserts the package and its imported edges, the caller must hold the lock.
+func (a *Packages) addPackage(p *Package) {
+	a.byPath[p.path] = p
+	for _, importPath := range p.imported {
+		if a.importedBy[importPath] == nil {
+			a.importedBy[importPath] = map[string]struct{}{}
+		}
+		a.importedBy[importPath][p.path] = struct{}{}
+	}
+}
+
+// migrateTemplateSnapshot reads the documents written by the json template, which had no
+// version, did not have the exported variables and had a trailing comma in every package.
+func migrateTemplateSnapshot(data []byte) (*Snapshot, error) {
+	old := struct {
+		Packages []struct {
+			Name       string   `json:"name"`
+			Path       string   `json:"path"`
+			Imported   []string `json:"imported"`
+			ImportedBy []string `json:"importedBy"`
+			Funcs      []string `json:"exported_funcs"`
+			Types      []string `json:"exported_types"`
+		} `json:"packages"`
+	}{}
+	if err := json.Unmarshal(data, &old); err != nil {
+		return nil, err
+	}
+	s := &Snapshot{Version: SnapshotVersion}
+	for _, op := range old.Packages {
+		s.Packages = append(s.Packages, SnapshotPackage{
+			Name:              op.Name,
+			Path:              op.Path,
+			Imported:          sortedStrings(op.Imported),
+			ImportedBy:        sortedStrings(op.ImportedBy),
+			ExportedFunctions: sortedStrings(op.Funcs),
+			ExportedTypes:     namedTypes(sortedStrings(op.Types)),
+		})
+	}
+	return s, nil
+}
+
+// migrateMapSnapshot reads the documents which map the package paths to the packages, and
+// keep the lists as sets (maps with empty values).
+func migrateMapSnapshot(data []byte) (*Snapshot, error) {
+	type set map[string]struct{}
+	var old map[string]struct {
+		Name                   string
+		Path                   string
+		DirectImportedPackages set
+		ImportedByPackages     set
+		ExportedTypes          set
+		ExportedVariables      set
+		ExportedFunctions      set
+	}
+	if err := json.Unmarshal(data, &old); err != nil {
+		return nil, err
+	}
+	keys := func(s set) []string {
+		var res []string
+		for k := range s {
+			res = append(res, k)
+		}
+		return sortedStrings(res)
+	}
+	s := &Snapshot{Version: SnapshotVersion}
+	for pkgPath, op := range old {
+		if op.Path == "" {
+			op.Path = pkgPath
+		}
+		s.Packages = append(s.Packages, SnapshotPackage{
+			Name:              op.Name,
+			Path:              op.Path,
+			Imported:          keys(op.DirectImportedPackages),
+			ImportedBy:        keys(op.ImportedByPackages),
+			ExportedFunctions: keys(op.ExportedFunctions),
+			ExportedTypes:     namedTypes(keys(op.ExportedTypes)),
+			ExportedVariables: namedValues(keys(op.ExportedVariables)),
+		})
+	}
+	return s, nil
+}
+
+// removeTrailingCommas removes the commas which are followed by a closing bracket or brace,
+// ignoring the ones inside the strings.
+func removeTrailingCommas(data []byte) []byte {
+	res := make([]byte, 0, len(data))
+	inString, escaped := false, false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case inString:
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		case c == ',':
+			j := i + 1
+			for j < len(data) && (data[j] == ' ' || data[j] == '\t' || data[j] == '\n' || data[j] == '\r') {
+				j++
+			}
+			if j < len(data) && (data[j] == '}' || data[j] == ']') {
+				continue
+			}
+		}
+		res = append(res, c)
+	}
+	return res
+}
+
+func sortedStrings(items []string) []string {
+	sort.Strings(items)
+	return items
+}
+
+// namedTypes returns the types without methods, for the snapshots which only had the type names.
+func namedTypes(names []string) []Type {
+	var res []Type
+	for _, name := range names {
+		res = append(res, Type{Name: name})
+	}
+	return res
+}
+
+func namedValues(names []string) []Value {
+	var res []Value
+	for _, name := range names {
+		res = append(res, Value{Name: name})
+	}
+	return res
+}
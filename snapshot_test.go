@@ -0,0 +1,119 @@
+package godeep
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files of the tests")
+
+// checkGolden compares the data with the golden file, or rewrites the golden file if the tests
+// are run with -update.
+func checkGolden(t *testing.T, golden string, data []byte) {
+	t.Helper()
+	golden = filepath.Join("testdata", golden)
+	if *update {
+		if err := os.WriteFile(golden, append(data, '\n'), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	expected, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(bytes.TrimSuffix(expected, []byte("\n")), data) {
+		t.Errorf("snapshot does not match %s, run the tests with -update to see the difference:\n%s", golden, data)
+	}
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	dir := writeModule(t, map[string]string{
+		"go.mod": "module example.com/a\n\ngo 1.22\n",
+		"a.go": `package a
+
+import "example.com/a/b"
+
+var Default = b.New()
+`,
+		"b/b.go": `package b
+
+import (
+	"errors"
+
+	"example.com/a/c"
+)
+
+type Mode int
+
+const (
+	ModeFast Mode = iota
+	ModeSafe
+)
+
+var ErrClosed = errors.New("closed")
+
+type Client struct {
+	Mode Mode ` + "`json:\"mode\"`" + `
+	c.Set[string]
+}
+
+func New() *Client { return &Client{} }
+
+func (cl *Client) Do(ch <-chan int) error { return c.Check(ch) }
+`,
+		"b/b_test.go": `package b
+
+import "testing"
+
+func TestNew(t *testing.T) {
+	_ = New()
+}
+`,
+		"c/c.go": `package c
+
+type Set[T comparable] map[T]struct{}
+
+func Check[T any](v T) error { return nil }
+
+func Map[T, R any](xs []T, f func(T) R) []R { return nil }
+`,
+	})
+	a := analyze(t, dir)
+	data := bytes.ReplaceAll(a.Marshal(), []byte(dir), []byte("/src/a"))
+	checkGolden(t, "snapshot/analyzed.golden.json", data)
+
+	b := InitPackages()
+	if err := b.Unmarshal(data); err != nil {
+		t.Fatal(err)
+	}
+	if remarshaled := b.Marshal(); !bytes.Equal(remarshaled, data) {
+		t.Errorf("snapshot is changed by Unmarshal and Marshal:\n%s", remarshaled)
+	}
+}
+
+func TestSnapshotMigrations(t *testing.T) {
+	for _, name := range []string{"template", "map"} {
+		t.Run(name, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join("testdata", "snapshot", name+".json"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			a := InitPackages()
+			if err := a.Unmarshal(data); err != nil {
+				t.Fatal(err)
+			}
+			checkGolden(t, filepath.Join("snapshot", name+".golden.json"), a.Marshal())
+		})
+	}
+}
+
+func TestSnapshotNewerVersion(t *testing.T) {
+	a := InitPackages()
+	if err := a.Unmarshal([]byte(`{"version": 100, "packages": []}`)); err == nil {
+		t.Error("expected an error for a snapshot newer than the supported version")
+	}
+}
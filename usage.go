@@ -0,0 +1,319 @@
+package godeep
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"golang.org/x/tools/go/packages"
+	"sort"
+	"strings"
+)
+
+// SymbolUsage is an exported symbol of an imported package with the number of its references.
+// Methods and fields are prefixed by their type, i.e. 'Client.Do'.
+type SymbolUsage struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// Usage returns the symbols of the imported package 'to' which are referenced by the package and
+// its internal test files, sorted by name. The references of the package to its own symbols are
+// returned for its own path.
+func (p *Package) Usage(to string) []SymbolUsage {
+	return p.usage[to]
+}
+
+// Weight returns the number of the references to the symbols of the imported package 'to'.
+func (p *Package) Weight(to string) int {
+	w := 0
+	for _, u := range p.usage[to] {
+		w += u.Count
+	}
+	return w
+}
+
+type ReferenceKind int
+
+const (
+	ReferenceCall ReferenceKind = iota
+	ReferenceType
+	ReferenceEmbed
+	ReferenceValue
+)
+
+func (k ReferenceKind) String() string {
+	switch k {
+	case ReferenceCall:
+		return "call"
+	case ReferenceType:
+		return "type"
+	case ReferenceEmbed:
+		return "embed"
+	case ReferenceValue:
+		return "value"
+	}
+	return "unknown"
+}
+
+// Reference is a reference to an exported symbol of another package, which is either imported or
+// a non-standard package reached through the imported ones, i.e. the result of a function. The
+// type references include the conversions, and the embedded types are only reported as embeddings.
+type Reference struct {
+	Package  string
+	Symbol   string
+	Kind     ReferenceKind
+	Position token.Position
+}
+
+// CallSite is a reference of an analyzed package to a symbol of another package.
+type CallSite struct {
+	From string
+	Reference
+}
+
+// CallSites returns the references of the analyzed packages to the symbols of the target package,
+// or the packages under the target path if it is a module, sorted by the referencing package,
+// the symbol and the position. The references of the internal test files are included.
+func (a *Packages) CallSites(target string) []CallSite {
+	a.mtx.RLock()
+	defer a.mtx.RUnlock()
+	var res []CallSite
+	for _, pkgPath := range keys(a.byPath) {
+		for _, r := range a.byPath[pkgPath].references {
+			if r.Package == pkgPath {
+				continue
+			}
+			if r.Package == target || strings.HasPrefix(r.Package, target+"/") {
+				res = append(res, CallSite{From: pkgPath, Reference: r})
+			}
+		}
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		switch {
+		case res[i].From != res[j].From:
+			return res[i].From < res[j].From
+		case res[i].Package != res[j].Package:
+			return res[i].Package < res[j].Package
+		case res[i].Symbol != res[j].Symbol:
+			return res[i].Symbol < res[j].Symbol
+		case res[i].Position.Filename != res[j].Position.Filename:
+			return res[i].Position.Filename < res[j].Position.Filename
+		}
+		return res[i].Position.Offset < res[j].Position.Offset
+	})
+	return res
+}
+
+// references returns the references to the exported symbols of the package itself, the imported
+// packages and the packages which are only imported indirectly, except the standard library ones
+// in std. If testOnly is set, only the references in the test files are returned.
+func references(pkg *packages.Package, testOnly bool, std map[string]bool) []Reference {
+	if pkg.TypesInfo == nil {
+		return nil
+	}
+	// The references to the symbols of the package itself are kept too
+	imported := map[string]bool{pkg.PkgPath: true}
+	for _, ipkg := range pkg.Imports {
+		imported[ipkg.PkgPath] = true
+	}
+	owners := fieldOwners(pkg)
+	calls, embeds := callAndEmbedIdents(pkg)
+	var res []Reference
+	for ident, o := range pkg.TypesInfo.Uses {
+		if o.Pkg() == nil || !o.Exported() || (std[o.Pkg().Path()] && !imported[o.Pkg().Path()]) {
+			continue
+		}
+		name := symbolName(o, owners[ident])
+		if name == "" {
+			continue
+		}
+		pos := pkg.Fset.Position(ident.Pos())
+		if testOnly != strings.HasSuffix(pos.Filename, "_test.go") {
+			continue
+		}
+		r := Reference{
+			Package:  o.Pkg().Path(),
+			Symbol:   name,
+			Kind:     ReferenceValue,
+			Position: pos,
+		}
+		_, isType := o.(*types.TypeName)
+		switch {
+		case embeds[ident]:
+			r.Kind = ReferenceEmbed
+		case isType:
+			r.Kind = ReferenceType
+		case calls[ident]:
+			r.Kind = ReferenceCall
+		}
+		res = append(res, r)
+	}
+	return res
+}
+
+// usage counts the references to the symbols of the package itself and every imported package,
+// the references to the packages which are only imported indirectly are not counted.
+func usage(refs []Reference, pkgPath string, imported []string) map[string][]SymbolUsage {
+	counts := map[string]map[string]int{}
+	for _, r := range refs {
+		if r.Package != pkgPath && !containsString(imported, r.Package) {
+			continue
+		}
+		if counts[r.Package] == nil {
+			counts[r.Package] = map[string]int{}
+		}
+		counts[r.Package][r.Symbol]++
+	}
+	res := make(map[string][]SymbolUsage, len(counts))
+	for pkgPath, symbols := range counts {
+		for name, count := range symbols {
+			res[pkgPath] = append(res[pkgPath], SymbolUsage{Name: name, Count: count})
+		}
+		sort.Slice(res[pkgPath], func(i, j int) bool {
+			return res[pkgPath][i].Name < res[pkgPath][j].Name
+		})
+	}
+	return res
+}
+
+// callAndEmbedIdents returns the identifiers which are called, and the identifiers of the types
+// which are embedded in the structs and the interfaces.
+func callAndEmbedIdents(pkg *packages.Package) (calls, embeds map[*ast.Ident]bool) {
+	calls, embeds = map[*ast.Ident]bool{}, map[*ast.Ident]bool{}
+	for _, f := range pkg.Syntax {
+		ast.Inspect(f, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.CallExpr:
+				if ident := exprIdent(n.Fun); ident != nil {
+					calls[ident] = true
+				}
+			case *ast.StructType:
+				for _, field := range n.Fields.List {
+					if len(field.Names) == 0 {
+						if ident := exprIdent(field.Type); ident != nil {
+							embeds[ident] = true
+						}
+					}
+				}
+			case *ast.InterfaceType:
+				for _, field := range n.Methods.List {
+					if len(field.Names) == 0 {
+						if ident := exprIdent(field.Type); ident != nil {
+							embeds[ident] = true
+						}
+					}
+				}
+			}
+			return true
+		})
+	}
+	return calls, embeds
+}
+
+// exprIdent returns the identifier which names the expression, i.e. 'Do' for 'c.Do', 'Map' for
+// 'b.Map[int]' and 'Client' for '*b.Client'.
+func exprIdent(expr ast.Expr) *ast.Ident {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e
+	case *ast.SelectorExpr:
+		return e.Sel
+	case *ast.ParenExpr:
+		return exprIdent(e.X)
+	case *ast.StarExpr:
+		return exprIdent(e.X)
+	case *ast.IndexExpr:
+		return exprIdent(e.X)
+	case *ast.IndexListExpr:
+		return exprIdent(e.X)
+	}
+	return nil
+}
+
+// symbolName returns the name of the object as it is declared in its package, the fields and
+// the methods are prefixed by the name of their type. It returns an empty string for the objects
+// which are not symbols of a package, i.e. the imported package names.
+func symbolName(o types.Object, owner string) string {
+	switch o := o.(type) {
+	case *types.PkgName, *types.Label:
+		return ""
+	case *types.Func:
+		if recv := o.Type().(*types.Signature).Recv(); recv != nil {
+			if named := namedOf(recv.Type()); named != nil {
+				return fmt.Sprintf("%s.%s", named.Obj().Name(), o.Name())
+			}
+			return o.Name()
+		}
+	case *types.Var:
+		if o.IsField() {
+			if owner == "" {
+				return o.Name()
+			}
+			return fmt.Sprintf("%s.%s", owner, o.Name())
+		}
+	}
+	if o.Parent() != o.Pkg().Scope() {
+		return ""
+	}
+	return o.Name()
+}
+
+// fieldOwners returns the name of the struct type which declares the field of the selectors and
+// the keys of the composite literals. The selected field could be promoted from an embedded type.
+func fieldOwners(pkg *packages.Package) map[*ast.Ident]string {
+	owners := map[*ast.Ident]string{}
+	for expr, sel := range pkg.TypesInfo.Selections {
+		if sel.Kind() != types.FieldVal {
+			continue
+		}
+		t := sel.Recv()
+		for _, idx := range sel.Index()[:len(sel.Index())-1] {
+			s, ok := deref(t).Underlying().(*types.Struct)
+			if !ok {
+				break
+			}
+			t = s.Field(idx).Type()
+		}
+		if named := namedOf(t); named != nil {
+			owners[expr.Sel] = named.Obj().Name()
+		}
+	}
+	for _, f := range pkg.Syntax {
+		ast.Inspect(f, func(n ast.Node) bool {
+			lit, ok := n.(*ast.CompositeLit)
+			if !ok {
+				return true
+			}
+			named := namedOf(pkg.TypesInfo.TypeOf(lit))
+			if named == nil {
+				return true
+			}
+			for _, elt := range lit.Elts {
+				if kv, ok := elt.(*ast.KeyValueExpr); ok {
+					if key, ok := kv.Key.(*ast.Ident); ok {
+						owners[key] = named.Obj().Name()
+					}
+				}
+			}
+			return true
+		})
+	}
+	return owners
+}
+
+func deref(t types.Type) types.Type {
+	if ptr, ok := t.(*types.Pointer); ok {
+		return ptr.Elem()
+	}
+	return t
+}
+
+// namedOf returns the named type of t or the named type which t points to.
+func namedOf(t types.Type) *types.Named {
+	if t == nil {
+		return nil
+	}
+	named, _ := deref(t).(*types.Named)
+	return named
+}
@@ -0,0 +1,65 @@
+package godeep
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestCallSitesThroughWrapper(t *testing.T) {
+	dir := writeModule(t, map[string]string{
+		"main/go.mod": "module example.com/s\n\ngo 1.22\n\nrequire example.com/lib v0.0.0\n\nreplace example.com/lib => ../lib\n",
+		"main/wrap/wrap.go": `package wrap
+
+import "example.com/lib"
+
+func Logger() *lib.Logger { return lib.New() }
+`,
+		"main/app/app.go": `package app
+
+import (
+	"fmt"
+
+	"example.com/s/wrap"
+)
+
+func Run() {
+	wrap.Logger().Info("x")
+	fmt.Println()
+}
+`,
+		"lib/go.mod": "module example.com/lib\n\ngo 1.22\n",
+		"lib/lib.go": `package lib
+
+type Logger struct{}
+
+func New() *Logger { return &Logger{} }
+
+func (*Logger) Info(msg string) {}
+`,
+	})
+	a := analyze(t, filepath.Join(dir, "main"))
+
+	var sites []string
+	for _, s := range a.CallSites("example.com/lib") {
+		sites = append(sites, s.From+" "+s.Symbol)
+	}
+	expected := []string{"example.com/s/app Logger.Info", "example.com/s/wrap Logger", "example.com/s/wrap New"}
+	if len(sites) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, sites)
+	}
+	for idx := range expected {
+		if sites[idx] != expected[idx] {
+			t.Errorf("expected %v, got %v", expected, sites)
+			break
+		}
+	}
+
+	// The usage only counts the imported packages, it is the weight of the import edges
+	app := a.GetByPath("example.com/s/app")
+	if u := app.Usage("example.com/lib"); len(u) != 0 {
+		t.Errorf("expected no usage of the package which is not imported, got %v", u)
+	}
+	if app.Weight("example.com/s/wrap") != 1 || app.Weight("fmt") != 1 {
+		t.Errorf("expected the usage of the imported packages, got %v", app.usage)
+	}
+}